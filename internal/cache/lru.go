@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Least recently used cache, safe for concurrent use
+type LRU[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[K]*list.Element
+	order    *list.List
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: capacity,
+		entries:  make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+
+	return element.Value.(*entry[K, V]).value, true
+}
+
+func (c *LRU[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.capacity < 1 {
+		return
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*entry[K, V]).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+
+	if c.order.Len() > c.capacity {
+		c.removeOldest()
+	}
+}
+
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[K]*list.Element)
+	c.order.Init()
+}
+
+func (c *LRU[K, V]) removeOldest() {
+	oldest := c.order.Back()
+	if oldest == nil {
+		return
+	}
+
+	c.order.Remove(oldest)
+	delete(c.entries, oldest.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LRU_Get_givenAddedKey_shouldReturnValue(t *testing.T) {
+	lru := NewLRU[string, int](2)
+	lru.Add("a", 1)
+
+	value, ok := lru.Get("a")
+
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+}
+
+func Test_LRU_Add_givenFullCache_shouldEvictLeastRecentlyUsed(t *testing.T) {
+	lru := NewLRU[string, int](2)
+	lru.Add("a", 1)
+	lru.Add("b", 2)
+	_, _ = lru.Get("a")
+	lru.Add("c", 3)
+
+	_, aOk := lru.Get("a")
+	_, bOk := lru.Get("b")
+	_, cOk := lru.Get("c")
+
+	assert.True(t, aOk)
+	assert.False(t, bOk)
+	assert.True(t, cOk)
+	assert.Equal(t, 2, lru.Len())
+}
+
+func Test_LRU_Add_givenExistingKey_shouldReplaceValue(t *testing.T) {
+	lru := NewLRU[string, int](2)
+	lru.Add("a", 1)
+	lru.Add("a", 2)
+
+	value, _ := lru.Get("a")
+
+	assert.Equal(t, 2, value)
+	assert.Equal(t, 1, lru.Len())
+}
+
+func Test_LRU_Add_givenZeroCapacity_shouldNotStore(t *testing.T) {
+	lru := NewLRU[string, int](0)
+	lru.Add("a", 1)
+
+	_, ok := lru.Get("a")
+
+	assert.False(t, ok)
+}
+
+func Test_LRU_Purge_shouldRemoveAll(t *testing.T) {
+	lru := NewLRU[string, int](2)
+	lru.Add("a", 1)
+	lru.Purge()
+
+	_, ok := lru.Get("a")
+
+	assert.False(t, ok)
+	assert.Equal(t, 0, lru.Len())
+}
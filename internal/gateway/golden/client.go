@@ -0,0 +1,207 @@
+package golden
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/pldag"
+	"github.com/ourstudio-se/puan-sdk-go/internal/weights"
+	"github.com/ourstudio-se/puan-sdk-go/puan"
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+// Recorded answer of a solver to a query. Queries the solver could not
+// solve are recorded with the message, and replayed as puanerror.SolverFailed.
+type goldenFile struct {
+	Solutions    []puan.Solution `json:"solutions,omitempty"`
+	SolverFailed string          `json:"solverFailed,omitempty"`
+}
+
+// Solves queries with another client, writing each answer to a golden
+// file in the directory, named by the canonical hash of the query
+type RecordingClient struct {
+	client    puan.SolverClient
+	directory string
+}
+
+func NewRecordingClient(client puan.SolverClient, directory string) *RecordingClient {
+	return &RecordingClient{
+		client:    client,
+		directory: directory,
+	}
+}
+
+func (c *RecordingClient) Solve(query *puan.SolverQuery) (puan.Solution, error) {
+	key := newQueryKey(query.Polyhedron(), query.Variables(), query.Weights())
+
+	solution, err := c.client.Solve(query)
+	if err != nil {
+		return nil, c.recordError(key, err)
+	}
+
+	err = c.write(key, goldenFile{Solutions: []puan.Solution{solution}})
+	if err != nil {
+		return nil, err
+	}
+
+	return solution, nil
+}
+
+func (c *RecordingClient) SolveWithManyWeights(
+	query *puan.MultiWeightSolverQuery,
+) ([]puan.Solution, error) {
+	key := newQueryKey(query.Polyhedron(), query.Variables(), query.WeightGroups()...)
+
+	solutions, err := c.client.SolveWithManyWeights(query)
+	if err != nil {
+		return nil, c.recordError(key, err)
+	}
+
+	err = c.write(key, goldenFile{Solutions: solutions})
+	if err != nil {
+		return nil, err
+	}
+
+	return solutions, nil
+}
+
+// Records failures of the solver, other errors are only returned
+func (c *RecordingClient) recordError(key string, err error) error {
+	if !errors.Is(err, puanerror.SolverFailed) {
+		return err
+	}
+
+	writeErr := c.write(key, goldenFile{SolverFailed: err.Error()})
+	if writeErr != nil {
+		return writeErr
+	}
+
+	return err
+}
+
+// Writes through a temporary file, so that concurrent
+// recordings of the same query never leave a partial file
+func (c *RecordingClient) write(key string, file goldenFile) error {
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+
+	err = os.MkdirAll(c.directory, 0o755)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+
+	temporary, err := os.CreateTemp(c.directory, key+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer os.Remove(temporary.Name())
+
+	_, err = temporary.Write(append(data, '\n'))
+	closeErr := temporary.Close()
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	if closeErr != nil {
+		return errors.Wrap(closeErr, 0)
+	}
+
+	err = os.Rename(temporary.Name(), goldenPath(c.directory, key))
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+
+	return nil
+}
+
+// Serves queries from the golden files of a RecordingClient, without
+// a solver. Queries not recorded fail with puanerror.NotFound.
+type ReplayClient struct {
+	directory string
+}
+
+func NewReplayClient(directory string) *ReplayClient {
+	return &ReplayClient{
+		directory: directory,
+	}
+}
+
+func (c *ReplayClient) Solve(query *puan.SolverQuery) (puan.Solution, error) {
+	solutions, err := c.read(query.Polyhedron(), query.Variables(), query.Weights())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(solutions) != 1 {
+		return nil, errors.Errorf(
+			"golden file has %d solutions, expected 1",
+			len(solutions),
+		)
+	}
+
+	return solutions[0], nil
+}
+
+func (c *ReplayClient) SolveWithManyWeights(
+	query *puan.MultiWeightSolverQuery,
+) ([]puan.Solution, error) {
+	solutions, err := c.read(query.Polyhedron(), query.Variables(), query.WeightGroups()...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(solutions) != len(query.WeightGroups()) {
+		return nil, errors.Errorf(
+			"golden file has %d solutions, expected %d",
+			len(solutions),
+			len(query.WeightGroups()),
+		)
+	}
+
+	return solutions, nil
+}
+
+func (c *ReplayClient) read(
+	polyhedron *pldag.Polyhedron,
+	variables []string,
+	weightGroups ...weights.Weights,
+) ([]puan.Solution, error) {
+	key := newQueryKey(polyhedron, variables, weightGroups...)
+	path := goldenPath(c.directory, key)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.Errorf(
+			"%w: no golden file %s for the query, record it with a RecordingClient",
+			puanerror.NotFound,
+			path,
+		)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	var file goldenFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, errors.Errorf("invalid golden file %s: %v", path, err)
+	}
+
+	if file.SolverFailed != "" {
+		return nil, errors.Errorf(
+			"%w: recorded in %s: %s",
+			puanerror.SolverFailed,
+			path,
+			file.SolverFailed,
+		)
+	}
+
+	return file.Solutions, nil
+}
+
+func goldenPath(directory, key string) string {
+	return filepath.Join(directory, key+".json")
+}
@@ -0,0 +1,177 @@
+package golden
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-errors/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/pldag"
+	"github.com/ourstudio-se/puan-sdk-go/internal/weights"
+	"github.com/ourstudio-se/puan-sdk-go/puan"
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+// x + y = 1
+func newXorPolyhedron() *pldag.Polyhedron {
+	return pldag.NewPolyhedron(
+		[][]int{
+			{1, 1},
+			{-1, -1},
+		},
+		[]int{1, -1},
+	)
+}
+
+// Answers every query with the solutions, or fails with the error
+type stubSolverClient struct {
+	solutions []puan.Solution
+	err       error
+}
+
+func (c stubSolverClient) Solve(_ *puan.SolverQuery) (puan.Solution, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return c.solutions[0], nil
+}
+
+func (c stubSolverClient) SolveWithManyWeights(
+	_ *puan.MultiWeightSolverQuery,
+) ([]puan.Solution, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return c.solutions, nil
+}
+
+func Test_ReplayClient_Solve_givenRecordedQuery_shouldReturnRecordedSolution(t *testing.T) {
+	directory := t.TempDir()
+	query := puan.NewSolverQuery(
+		newXorPolyhedron(),
+		[]string{"x", "y"},
+		weights.Weights{"y": 1},
+	)
+
+	client := stubSolverClient{solutions: []puan.Solution{{"x": 0, "y": 1}}}
+	recorded, err := NewRecordingClient(client, directory).Solve(query)
+	require.NoError(t, err)
+
+	replayed, err := NewReplayClient(directory).Solve(query)
+
+	require.NoError(t, err)
+	assert.Equal(t, puan.Solution{"x": 0, "y": 1}, recorded)
+	assert.Equal(t, recorded, replayed)
+}
+
+func Test_ReplayClient_SolveWithManyWeights_givenRecordedQuery_shouldReturnRecordedSolutions(
+	t *testing.T,
+) {
+	directory := t.TempDir()
+	query := puan.NewMultiWeightSolverQuery(
+		newXorPolyhedron(),
+		[]string{"x", "y"},
+		[]weights.Weights{{"x": 1}, {"y": 1}},
+	)
+
+	client := stubSolverClient{solutions: []puan.Solution{{"x": 1, "y": 0}, {"x": 0, "y": 1}}}
+	recorded, err := NewRecordingClient(client, directory).SolveWithManyWeights(query)
+	require.NoError(t, err)
+
+	replayed, err := NewReplayClient(directory).SolveWithManyWeights(query)
+
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+}
+
+func Test_ReplayClient_Solve_givenQueryNotRecorded_shouldReturnNotFound(t *testing.T) {
+	query := puan.NewSolverQuery(newXorPolyhedron(), []string{"x", "y"}, weights.Weights{})
+
+	_, err := NewReplayClient(t.TempDir()).Solve(query)
+
+	assert.ErrorIs(t, err, puanerror.NotFound)
+}
+
+func Test_ReplayClient_Solve_givenRecordedSolverFailure_shouldReturnSolverFailed(
+	t *testing.T,
+) {
+	directory := t.TempDir()
+	// x + y = 1 and x + y >= 2
+	polyhedron := pldag.NewPolyhedron(
+		[][]int{
+			{1, 1},
+			{-1, -1},
+			{-1, -1},
+		},
+		[]int{1, -1, -2},
+	)
+	query := puan.NewSolverQuery(polyhedron, []string{"x", "y"}, weights.Weights{})
+
+	client := stubSolverClient{
+		err: errors.Errorf("%w: no feasible solution", puanerror.SolverFailed),
+	}
+	_, err := NewRecordingClient(client, directory).Solve(query)
+	require.ErrorIs(t, err, puanerror.SolverFailed)
+
+	_, err = NewReplayClient(directory).Solve(query)
+
+	assert.ErrorIs(t, err, puanerror.SolverFailed)
+}
+
+func Test_RecordingClient_Solve_shouldWriteOneFilePerQuery(t *testing.T) {
+	directory := t.TempDir()
+	client := NewRecordingClient(
+		stubSolverClient{solutions: []puan.Solution{{"x": 1, "y": 0}}},
+		directory,
+	)
+	query := puan.NewSolverQuery(newXorPolyhedron(), []string{"x", "y"}, weights.Weights{})
+
+	for range 2 {
+		_, err := client.Solve(query)
+		require.NoError(t, err)
+	}
+
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func Test_newQueryKey_givenZeroWeights_shouldEqualKeyWithout(t *testing.T) {
+	polyhedron := newXorPolyhedron()
+	variables := []string{"x", "y"}
+
+	withZero := newQueryKey(polyhedron, variables, weights.Weights{"x": 1, "y": 0})
+	without := newQueryKey(polyhedron, variables, weights.Weights{"x": 1})
+
+	assert.Equal(t, without, withZero)
+}
+
+func Test_newQueryKey_givenDifferentQueries_shouldDiffer(t *testing.T) {
+	polyhedron := newXorPolyhedron()
+	variables := []string{"x", "y"}
+	key := newQueryKey(polyhedron, variables, weights.Weights{"x": 1})
+
+	assert.NotEqual(t, key, newQueryKey(polyhedron, variables, weights.Weights{"y": 1}))
+	assert.NotEqual(t, key, newQueryKey(polyhedron, []string{"y", "x"}, weights.Weights{"x": 1}))
+	assert.NotEqual(
+		t,
+		key,
+		newQueryKey(pldag.NewPolyhedron([][]int{{1, 1}}, []int{1}), variables, weights.Weights{"x": 1}),
+	)
+}
+
+func Test_newQueryKey_givenVariableNamesWithSeparators_shouldDiffer(t *testing.T) {
+	polyhedron := pldag.NewPolyhedron(nil, nil)
+
+	joined := newQueryKey(polyhedron, []string{"x,y"}, weights.Weights{"x,y": 1})
+	split := newQueryKey(polyhedron, []string{"x", "y"}, weights.Weights{"x,y": 1})
+	combined := newQueryKey(polyhedron, []string{"x"}, weights.Weights{"x=1 y": 1})
+	separate := newQueryKey(polyhedron, []string{"x"}, weights.Weights{"x": 1, "y": 1})
+
+	assert.NotEqual(t, joined, split)
+	assert.NotEqual(t, combined, separate)
+}
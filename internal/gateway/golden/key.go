@@ -0,0 +1,54 @@
+package golden
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/pldag"
+	"github.com/ourstudio-se/puan-sdk-go/internal/weights"
+)
+
+// Canonical hash of a query, equal for queries with the same polyhedron,
+// variables and weights. Zero weights are left out, since they do not
+// change the solution, and weights are written in order of variable.
+// Variable names are prefixed by their length, so that names containing
+// separators cannot make different queries equal.
+func newQueryKey(
+	polyhedron *pldag.Polyhedron,
+	variables []string,
+	weightGroups ...weights.Weights,
+) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "columns %d\n", polyhedron.NrOfColumns())
+	for i, row := range polyhedron.Rows() {
+		for j, column := range row.Columns() {
+			fmt.Fprintf(&builder, "%d:%d ", column, row.Values()[j])
+		}
+		fmt.Fprintf(&builder, "<= %d\n", polyhedron.B()[i])
+	}
+
+	builder.WriteString("variables")
+	for _, variable := range variables {
+		fmt.Fprintf(&builder, " %d:%s", len(variable), variable)
+	}
+	builder.WriteString("\n")
+
+	for _, group := range weightGroups {
+		builder.WriteString("weights")
+		for _, variable := range slices.Sorted(maps.Keys(group)) {
+			if group[variable] != 0 {
+				fmt.Fprintf(&builder, " %d:%s=%d", len(variable), variable, group[variable])
+			}
+		}
+		builder.WriteString("\n")
+	}
+
+	hash := sha256.Sum256([]byte(builder.String()))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,63 @@
+package inproc
+
+import (
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/pldag"
+	"github.com/ourstudio-se/puan-sdk-go/internal/weights"
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+// Solves a query in process, without an external solver. The search is
+// exhaustive, so it is intended for small rulesets, e.g. in tests and
+// local development. Returns puanerror.SolverFailed if there is no solution.
+func Solve(
+	polyhedron *pldag.Polyhedron,
+	variables []string,
+	objective weights.Weights,
+) (map[string]int, error) {
+	if polyhedron.NrOfColumns() > len(variables) {
+		return nil, errors.Errorf(
+			"%w: polyhedron has %d columns but there are %d variables",
+			puanerror.InvalidArgument,
+			polyhedron.NrOfColumns(),
+			len(variables),
+		)
+	}
+
+	weights := make([]int, len(variables))
+	for i, variable := range variables {
+		weights[i] = objective[variable]
+	}
+
+	values, err := solve(polyhedron, weights)
+	if err != nil {
+		return nil, err
+	}
+
+	solution := make(map[string]int, len(variables))
+	for i, variable := range variables {
+		solution[variable] = values[i]
+	}
+
+	return solution, nil
+}
+
+// Solves the polyhedron once per objective, in order
+func SolveWithManyWeights(
+	polyhedron *pldag.Polyhedron,
+	variables []string,
+	objectives []weights.Weights,
+) ([]map[string]int, error) {
+	solutions := make([]map[string]int, len(objectives))
+	for i, objective := range objectives {
+		solution, err := Solve(polyhedron, variables, objective)
+		if err != nil {
+			return nil, err
+		}
+
+		solutions[i] = solution
+	}
+
+	return solutions, nil
+}
@@ -0,0 +1,70 @@
+package inproc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/pldag"
+	"github.com/ourstudio-se/puan-sdk-go/internal/weights"
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+// x + y = 1
+func newXorPolyhedron() *pldag.Polyhedron {
+	return pldag.NewPolyhedron(
+		[][]int{
+			{1, 1},
+			{-1, -1},
+		},
+		[]int{1, -1},
+	)
+}
+
+func Test_Solve_shouldMaximizeObjective(t *testing.T) {
+	solution, err := Solve(
+		newXorPolyhedron(),
+		[]string{"x", "y"},
+		weights.Weights{"x": -2, "y": 3},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"x": 0, "y": 1}, solution)
+}
+
+func Test_Solve_givenInfeasiblePolyhedron_shouldReturnSolverFailed(t *testing.T) {
+	// x + y = 1 and x + y >= 2
+	polyhedron := pldag.NewPolyhedron(
+		[][]int{
+			{1, 1},
+			{-1, -1},
+			{-1, -1},
+		},
+		[]int{1, -1, -2},
+	)
+
+	_, err := Solve(polyhedron, []string{"x", "y"}, weights.Weights{})
+
+	assert.ErrorIs(t, err, puanerror.SolverFailed)
+}
+
+func Test_Solve_givenMoreColumnsThanVariables_shouldReturnInvalidArgument(t *testing.T) {
+	_, err := Solve(newXorPolyhedron(), []string{"x"}, weights.Weights{})
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_SolveWithManyWeights_shouldSolveEachWeightGroup(t *testing.T) {
+	solutions, err := SolveWithManyWeights(
+		newXorPolyhedron(),
+		[]string{"x", "y"},
+		[]weights.Weights{
+			{"x": 1},
+			{"y": 1},
+		},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]int{{"x": 1, "y": 0}, {"x": 0, "y": 1}}, solutions)
+}
@@ -0,0 +1,240 @@
+package inproc
+
+import (
+	"slices"
+
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/pldag"
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+const unassigned = -1
+
+// Coefficient of a variable in a row
+type entry struct {
+	index int
+	value int
+}
+
+// Depth-first branch and bound over binary variables, maximizing the
+// objective subject to A x <= b. Rows are propagated after each
+// assignment, forcing variables that can only take one value.
+// Ties are broken towards the solution setting the earliest columns,
+// i.e. the lexicographically largest solution in column order, so that
+// the solution only depends on the query.
+type search struct {
+	rows    [][]entry
+	columns [][]entry
+	bias    []int
+	weights []int
+	// Variables in order of branching, largest absolute weight first
+	order []int
+
+	values []int
+	// Sum of the assigned variables of each row
+	activity []int
+	// Smallest possible sum of the unassigned variables of each row
+	minimum []int
+	// Assigned variables, in order of assignment
+	trail []int
+	// Objective value of the assigned variables
+	objective int
+	// Largest possible objective value of the unassigned variables
+	remaining int
+
+	best      []int
+	bestValue int
+}
+
+func newSearch(polyhedron *pldag.Polyhedron, weights []int) *search {
+	s := &search{
+		rows:     make([][]entry, len(polyhedron.Rows())),
+		columns:  make([][]entry, len(weights)),
+		bias:     polyhedron.B(),
+		weights:  weights,
+		values:   make([]int, len(weights)),
+		activity: make([]int, len(polyhedron.Rows())),
+		minimum:  make([]int, len(polyhedron.Rows())),
+	}
+
+	for i, row := range polyhedron.Rows() {
+		for j, column := range row.Columns() {
+			value := row.Values()[j]
+			s.rows[i] = append(s.rows[i], entry{index: column, value: value})
+			s.columns[column] = append(s.columns[column], entry{index: i, value: value})
+			s.minimum[i] += min(0, value)
+		}
+	}
+
+	for j, weight := range weights {
+		s.values[j] = unassigned
+		s.remaining += max(0, weight)
+		s.order = append(s.order, j)
+	}
+
+	slices.SortStableFunc(s.order, func(a, b int) int {
+		return abs(weights[b]) - abs(weights[a])
+	})
+
+	return s
+}
+
+func solve(polyhedron *pldag.Polyhedron, weights []int) ([]int, error) {
+	s := newSearch(polyhedron, weights)
+
+	allRows := make([]int, len(s.rows))
+	for i := range allRows {
+		allRows[i] = i
+	}
+
+	if s.propagate(allRows) {
+		s.branch(0)
+	}
+
+	if s.best == nil {
+		return nil, errors.Errorf("%w: no feasible solution", puanerror.SolverFailed)
+	}
+
+	return s.best, nil
+}
+
+func (s *search) branch(position int) {
+	if s.best != nil && !s.canImprove() {
+		return
+	}
+
+	for position < len(s.order) && s.values[s.order[position]] != unassigned {
+		position++
+	}
+
+	if position == len(s.order) {
+		// Only reached by better solutions, see canImprove
+		s.best = slices.Clone(s.values)
+		s.bestValue = s.objective
+		return
+	}
+
+	variable := s.order[position]
+	preferred := 0
+	if s.weights[variable] >= 0 {
+		preferred = 1
+	}
+
+	for _, value := range []int{preferred, 1 - preferred} {
+		mark := len(s.trail)
+		if s.assign(variable, value) && s.propagate(s.rowsOf(variable)) {
+			s.branch(position + 1)
+		}
+		s.undo(mark)
+	}
+}
+
+// Whether the unassigned variables can give a better objective value than
+// the best solution, or the same value and a lexicographically larger one
+func (s *search) canImprove() bool {
+	bound := s.objective + s.remaining
+	if bound != s.bestValue {
+		return bound > s.bestValue
+	}
+
+	for j, value := range s.values {
+		if value == unassigned {
+			value = 1
+		}
+
+		if value != s.best[j] {
+			return value > s.best[j]
+		}
+	}
+
+	return false
+}
+
+// Assigns the variable, returning false if a row can no longer be met
+func (s *search) assign(variable, value int) bool {
+	s.values[variable] = value
+	s.trail = append(s.trail, variable)
+	s.objective += s.weights[variable] * value
+	s.remaining -= max(0, s.weights[variable])
+
+	feasible := true
+	for _, e := range s.columns[variable] {
+		s.activity[e.index] += e.value * value
+		s.minimum[e.index] -= min(0, e.value)
+		if s.activity[e.index]+s.minimum[e.index] > s.bias[e.index] {
+			feasible = false
+		}
+	}
+
+	return feasible
+}
+
+func (s *search) undo(mark int) {
+	for len(s.trail) > mark {
+		variable := s.trail[len(s.trail)-1]
+		s.trail = s.trail[:len(s.trail)-1]
+
+		value := s.values[variable]
+		s.values[variable] = unassigned
+		s.objective -= s.weights[variable] * value
+		s.remaining += max(0, s.weights[variable])
+
+		for _, e := range s.columns[variable] {
+			s.activity[e.index] -= e.value * value
+			s.minimum[e.index] += min(0, e.value)
+		}
+	}
+}
+
+// Forces the unassigned variables of the rows whose other value would
+// exceed the slack of the row. Returns false if a row cannot be met.
+func (s *search) propagate(queue []int) bool {
+	queue = slices.Clone(queue)
+	for len(queue) > 0 {
+		row := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		slack := s.bias[row] - s.activity[row] - s.minimum[row]
+		if slack < 0 {
+			return false
+		}
+
+		for _, e := range s.rows[row] {
+			if s.values[e.index] != unassigned || abs(e.value) <= slack {
+				continue
+			}
+
+			value := 0
+			if e.value < 0 {
+				value = 1
+			}
+
+			if !s.assign(e.index, value) {
+				return false
+			}
+
+			queue = append(queue, s.rowsOf(e.index)...)
+			slack = s.bias[row] - s.activity[row] - s.minimum[row]
+		}
+	}
+
+	return true
+}
+
+func (s *search) rowsOf(variable int) []int {
+	rows := make([]int, len(s.columns[variable]))
+	for i, e := range s.columns[variable] {
+		rows[i] = e.index
+	}
+
+	return rows
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
@@ -0,0 +1,115 @@
+package inproc
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/pldag"
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+// x + y = 1, with the same weight on both
+func Test_solve_givenTie_shouldSetEarliestColumn(t *testing.T) {
+	polyhedron := pldag.NewPolyhedron([][]int{{1, 1}, {-1, -1}}, []int{1, -1})
+
+	values, err := solve(polyhedron, []int{-1, -1})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 0}, values)
+}
+
+func Test_solve_givenRandomPolyhedrons_shouldFindLargestOptimalSolution(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for range 200 {
+		nrOfColumns := 1 + random.Intn(8)
+		nrOfRows := random.Intn(6)
+
+		aMatrix := make([][]int, nrOfRows)
+		bVector := make([]int, nrOfRows)
+		for i := range aMatrix {
+			aMatrix[i] = make([]int, nrOfColumns)
+			for j := range aMatrix[i] {
+				aMatrix[i][j] = random.Intn(7) - 3
+			}
+			bVector[i] = random.Intn(7) - 2
+		}
+
+		objective := make([]int, nrOfColumns)
+		for j := range objective {
+			objective[j] = random.Intn(11) - 5
+		}
+
+		polyhedron := pldag.NewPolyhedron(aMatrix, bVector)
+		want, feasible := enumerate(aMatrix, bVector, objective)
+
+		values, err := solve(polyhedron, objective)
+
+		if !feasible {
+			assert.ErrorIs(t, err, puanerror.SolverFailed)
+			continue
+		}
+
+		require.NoError(t, err)
+		assert.Equal(t, want, values)
+	}
+}
+
+// Optimal solution by enumerating all assignments, the lexicographically
+// largest in column order on ties
+func enumerate(aMatrix [][]int, bVector []int, objective []int) ([]int, bool) {
+	var best []int
+	values := make([]int, len(objective))
+	for mask := 0; mask < 1<<len(objective); mask++ {
+		for j := range values {
+			values[j] = (mask >> j) & 1
+		}
+
+		if !satisfies(aMatrix, bVector, values) {
+			continue
+		}
+
+		if best == nil || isBetter(objective, values, best) {
+			best = slices.Clone(values)
+		}
+	}
+
+	return best, best != nil
+}
+
+func isBetter(objective []int, values []int, best []int) bool {
+	value, bestValue := objectiveValue(objective, values), objectiveValue(objective, best)
+	if value != bestValue {
+		return value > bestValue
+	}
+
+	for j := range values {
+		if values[j] != best[j] {
+			return values[j] > best[j]
+		}
+	}
+
+	return false
+}
+
+func satisfies(aMatrix [][]int, bVector []int, values []int) bool {
+	for i, row := range aMatrix {
+		if objectiveValue(row, values) > bVector[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func objectiveValue(coefficients []int, values []int) int {
+	value := 0
+	for j, coefficient := range coefficients {
+		value += coefficient * values[j]
+	}
+
+	return value
+}
@@ -213,17 +213,18 @@ func (c AuxiliaryConstraints) coefficientIDs() []string {
 	return ids
 }
 
-func (c AuxiliaryConstraint) asMatrixRow(variables []string) []int {
-	row := make([]int, len(variables))
-	for i, id := range variables {
-		if value, ok := c.coefficients[id]; ok {
-			row[i] = value
-		} else {
-			row[i] = 0
+// Coefficients not in variableIndices are left out
+func (c AuxiliaryConstraint) asSparseRow(variableIndices map[string]int) SparseRow {
+	var columns []int
+	var values []int
+	for id, value := range c.coefficients {
+		if index, ok := variableIndices[id]; ok {
+			columns = append(columns, index)
+			values = append(values, value)
 		}
 	}
 
-	return row
+	return NewSparseRow(columns, values)
 }
 
 func newConstraintID(coefficients Coefficients, bias Bias) (string, error) {
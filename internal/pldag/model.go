@@ -256,21 +256,27 @@ func CreatePolyhedron(
 	constraints Constraints,
 	assumeConstraints AuxiliaryConstraints,
 ) *Polyhedron {
-	var aMatrix [][]int
-	var bVector []int
-
 	constraintsWithSupport := toAuxiliaryConstraintsWithSupport(constraints)
 	var constraintsInMatrix AuxiliaryConstraints
 	constraintsInMatrix = append(constraintsInMatrix, constraintsWithSupport...)
 	constraintsInMatrix = append(constraintsInMatrix, assumeConstraints...)
+
+	variableIndices := make(map[string]int, len(variables))
+	for i, variable := range variables {
+		variableIndices[variable] = i
+	}
+
+	polyhedron := NewPolyhedron(nil, nil)
+	for range variables {
+		polyhedron.AddEmptyColumn()
+	}
+
 	for _, c := range constraintsInMatrix {
-		row := c.asMatrixRow(variables)
-		bias := int(c.bias)
-		aMatrix = append(aMatrix, row)
-		bVector = append(bVector, bias)
+		row := c.asSparseRow(variableIndices)
+		polyhedron.ExtendSparse(row, c.bias)
 	}
 
-	return NewPolyhedron(aMatrix, bVector)
+	return polyhedron
 }
 
 func (m *Model) PrimitiveVariables() []string {
@@ -156,7 +156,7 @@ func TestModel_NewPolyhedron(t *testing.T) {
 		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, -1},
 	}
 
-	assertEqual(t, expectedMatrix, lp.aMatrix, expectedVector, lp.bVector)
+	assertEqual(t, expectedMatrix, lp.A(), expectedVector, lp.B())
 }
 
 func TestModel_NewPolyhedron_withImpliesOr(t *testing.T) {
@@ -188,7 +188,7 @@ func TestModel_NewPolyhedron_withImpliesOr(t *testing.T) {
 		{0, 0, 0, 0, 0, -1},
 	}
 
-	assertEqual(t, expectedMatrix, lp.aMatrix, expectedVector, lp.bVector)
+	assertEqual(t, expectedMatrix, lp.A(), expectedVector, lp.B())
 }
 
 func assertEqual(
@@ -1,52 +1,264 @@
 package pldag
 
+import (
+	"sort"
+
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+// Polyhedron A x <= b, where A is stored row by row in sparse form.
+// Rows are never changed once added, so copies can share them.
 type Polyhedron struct {
-	aMatrix [][]int
-	bVector []int
+	rows        []SparseRow
+	nrOfColumns int
+	bVector     []int
+
+	// Set by PrecomputeSparseMatrix, cleared on any change
+	sparseMatrix *SparseMatrix
+}
+
+// Non-zero values of a matrix row, sorted by column
+type SparseRow struct {
+	columns []int
+	values  []int
+}
+
+func NewSparseRow(columns, values []int) SparseRow {
+	indices := make([]int, len(columns))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return columns[indices[i]] < columns[indices[j]]
+	})
+
+	row := SparseRow{}
+	for _, index := range indices {
+		if values[index] == 0 {
+			continue
+		}
+
+		row.columns = append(row.columns, columns[index])
+		row.values = append(row.values, values[index])
+	}
+
+	return row
+}
+
+func newSparseRowFromDense(dense []int) SparseRow {
+	row := SparseRow{}
+	for column, value := range dense {
+		if value != 0 {
+			row.columns = append(row.columns, column)
+			row.values = append(row.values, value)
+		}
+	}
+
+	return row
+}
+
+func (r SparseRow) Columns() []int {
+	return r.columns
+}
+
+func (r SparseRow) Values() []int {
+	return r.values
+}
+
+func (r SparseRow) maxColumn() int {
+	if len(r.columns) == 0 {
+		return -1
+	}
+
+	return r.columns[len(r.columns)-1]
+}
+
+func (r SparseRow) toDense(nrOfColumns int) []int {
+	dense := make([]int, nrOfColumns)
+	for i, column := range r.columns {
+		dense[column] = r.values[i]
+	}
+
+	return dense
 }
 
 func NewPolyhedron(aMatrix [][]int, bVector []int) *Polyhedron {
-	return &Polyhedron{
-		aMatrix: aMatrix,
+	polyhedron := &Polyhedron{
 		bVector: bVector,
 	}
+
+	for _, row := range aMatrix {
+		polyhedron.rows = append(polyhedron.rows, newSparseRowFromDense(row))
+		polyhedron.nrOfColumns = max(polyhedron.nrOfColumns, len(row))
+	}
+
+	if aMatrix != nil && polyhedron.rows == nil {
+		polyhedron.rows = []SparseRow{}
+	}
+
+	return polyhedron
+}
+
+func NewSparsePolyhedron(aMatrix SparseMatrix, bVector []int) (*Polyhedron, error) {
+	if err := aMatrix.validate(); err != nil {
+		return nil, err
+	}
+
+	if len(bVector) != aMatrix.shape.nrOfRows {
+		return nil, errors.Errorf(
+			"%w: b vector has %d values, expected %d",
+			puanerror.InvalidArgument,
+			len(bVector),
+			aMatrix.shape.nrOfRows,
+		)
+	}
+
+	columnsByRow := make([][]int, aMatrix.shape.nrOfRows)
+	valuesByRow := make([][]int, aMatrix.shape.nrOfRows)
+	for i, row := range aMatrix.rows {
+		columnsByRow[row] = append(columnsByRow[row], aMatrix.columns[i])
+		valuesByRow[row] = append(valuesByRow[row], aMatrix.values[i])
+	}
+
+	rows := make([]SparseRow, aMatrix.shape.nrOfRows)
+	for i := range rows {
+		rows[i] = NewSparseRow(columnsByRow[i], valuesByRow[i])
+	}
+
+	return &Polyhedron{
+		rows:        rows,
+		nrOfColumns: aMatrix.shape.nrOfColumns,
+		bVector:     bVector,
+	}, nil
 }
 
+// Dense representation of A. Prefer Rows or SparseMatrix for large polyhedrons.
 func (p *Polyhedron) A() [][]int {
-	return p.aMatrix
+	if p.rows == nil {
+		return nil
+	}
+
+	aMatrix := make([][]int, len(p.rows))
+	for i, row := range p.rows {
+		aMatrix[i] = row.toDense(p.nrOfColumns)
+	}
+
+	return aMatrix
+}
+
+func (p *Polyhedron) Rows() []SparseRow {
+	return p.rows
 }
 
 func (p *Polyhedron) B() []int {
 	return p.bVector
 }
 
+func (p *Polyhedron) NrOfColumns() int {
+	return p.nrOfColumns
+}
+
 func (p *Polyhedron) IsEmpty() bool {
-	return len(p.aMatrix) == 0
+	return len(p.rows) == 0
 }
 
-func (p *Polyhedron) AddEmptyColumn() {
-	for i := range p.aMatrix {
-		p.aMatrix[i] = append(p.aMatrix[i], 0)
+func (p *Polyhedron) Copy() *Polyhedron {
+	rows := make([]SparseRow, len(p.rows))
+	copy(rows, p.rows)
+
+	bVector := make([]int, len(p.bVector))
+	copy(bVector, p.bVector)
+
+	return &Polyhedron{
+		rows:        rows,
+		nrOfColumns: p.nrOfColumns,
+		bVector:     bVector,
 	}
 }
 
+func (p *Polyhedron) AddEmptyColumn() {
+	p.sparseMatrix = nil
+	p.nrOfColumns++
+}
+
 func (p *Polyhedron) Extend(row []int, bias Bias) {
-	p.aMatrix = append(p.aMatrix, row)
+	p.ExtendSparse(newSparseRowFromDense(row), bias)
+	p.nrOfColumns = max(p.nrOfColumns, len(row))
+}
+
+func (p *Polyhedron) ExtendSparse(row SparseRow, bias Bias) {
+	p.sparseMatrix = nil
+	p.rows = append(p.rows, row)
 	p.bVector = append(p.bVector, int(bias))
+	p.nrOfColumns = max(p.nrOfColumns, row.maxColumn()+1)
+}
+
+func (p *Polyhedron) RemoveRows(indices ...int) {
+	p.sparseMatrix = nil
+
+	remove := make(map[int]struct{}, len(indices))
+	for _, index := range indices {
+		remove[index] = struct{}{}
+	}
+
+	var rows []SparseRow
+	var bVector []int
+	for i := range p.rows {
+		if _, ok := remove[i]; ok {
+			continue
+		}
+
+		rows = append(rows, p.rows[i])
+		bVector = append(bVector, p.bVector[i])
+	}
+
+	p.rows = rows
+	p.bVector = bVector
+}
+
+// Finds the row assuming a single variable, i.e. -x <= -1
+func (p *Polyhedron) FindAssumedRow(column int) (int, bool) {
+	for i, row := range p.rows {
+		if p.bVector[i] != -1 || len(row.columns) != 1 {
+			continue
+		}
+
+		if row.columns[0] == column && row.values[0] == -1 {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+// Stores the sparse representation, so that it is not recalculated
+// each time the polyhedron is sent to the solver.
+// Must not be called concurrently with other methods.
+func (p *Polyhedron) PrecomputeSparseMatrix() {
+	sparseMatrix := p.calculateSparseMatrix()
+	p.sparseMatrix = &sparseMatrix
 }
 
 func (p *Polyhedron) SparseMatrix() SparseMatrix {
+	if p.sparseMatrix != nil {
+		return *p.sparseMatrix
+	}
+
+	return p.calculateSparseMatrix()
+}
+
+func (p *Polyhedron) calculateSparseMatrix() SparseMatrix {
 	var row []int
 	var column []int
 	var value []int
 
-	for rowIndex := range p.aMatrix {
-		for columIndex := range p.aMatrix[rowIndex] {
-			if p.aMatrix[rowIndex][columIndex] != 0 {
-				row = append(row, rowIndex)
-				column = append(column, columIndex)
-				value = append(value, p.aMatrix[rowIndex][columIndex])
-			}
+	for rowIndex, sparseRow := range p.rows {
+		for i, columnIndex := range sparseRow.columns {
+			row = append(row, rowIndex)
+			column = append(column, columnIndex)
+			value = append(value, sparseRow.values[i])
 		}
 	}
 
@@ -54,12 +266,9 @@ func (p *Polyhedron) SparseMatrix() SparseMatrix {
 }
 
 func (p *Polyhedron) shape() Shape {
-	if len(p.aMatrix) == 0 {
+	if len(p.rows) == 0 {
 		return Shape{}
 	}
 
-	nrOfRows := len(p.aMatrix)
-	nrOfColumns := len(p.aMatrix[0])
-
-	return NewShape(nrOfRows, nrOfColumns)
+	return NewShape(len(p.rows), p.nrOfColumns)
 }
@@ -37,9 +37,7 @@ func TestPolyhedron_Shape(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			p := Polyhedron{
-				aMatrix: tt.aMatrix,
-			}
+			p := NewPolyhedron(tt.aMatrix, nil)
 			assert.Equalf(t, tt.want, p.shape(), "shape()")
 		})
 	}
@@ -79,9 +77,7 @@ func TestPolyhedron_SparseMatrix(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			p := Polyhedron{
-				aMatrix: tt.aMatrix,
-			}
+			p := NewPolyhedron(tt.aMatrix, nil)
 			assert.Equalf(t, tt.want, p.SparseMatrix(), "SparseMatrix()")
 		})
 	}
@@ -124,11 +120,9 @@ func Test_AddEmptyColumn_givenSingleRow(t *testing.T) {
 
 	for _, tt := range theories {
 		t.Run(tt.name, func(t *testing.T) {
-			p := Polyhedron{
-				aMatrix: tt.aMatrix,
-			}
+			p := NewPolyhedron(tt.aMatrix, nil)
 			p.AddEmptyColumn()
-			assert.Equal(t, tt.want, p.aMatrix)
+			assert.Equal(t, tt.want, p.A())
 		})
 	}
 }
@@ -137,25 +131,129 @@ func Test_Extend_extendToPopulatedPolyhedron(t *testing.T) {
 	row := []int{0, 0, 1}
 	b := Bias(1)
 
-	polyhedron := Polyhedron{
-		aMatrix: [][]int{
+	polyhedron := NewPolyhedron(
+		[][]int{
 			{1, 1, 0},
 		},
-		bVector: []int{1},
-	}
+		[]int{1},
+	)
 
 	polyhedron.Extend(row, b)
-	assert.Equal(t, [][]int{{1, 1, 0}, {0, 0, 1}}, polyhedron.aMatrix)
-	assert.Equal(t, []int{1, 1}, polyhedron.bVector)
+	assert.Equal(t, [][]int{{1, 1, 0}, {0, 0, 1}}, polyhedron.A())
+	assert.Equal(t, []int{1, 1}, polyhedron.B())
 }
 
 func Test_Extend_extendToEmptyPolyhedron(t *testing.T) {
 	row := []int{0, 1}
 	b := Bias(1)
 
-	polyhedron := Polyhedron{}
+	polyhedron := NewPolyhedron(nil, nil)
 
 	polyhedron.Extend(row, b)
-	assert.Equal(t, [][]int{{0, 1}}, polyhedron.aMatrix)
-	assert.Equal(t, []int{1}, polyhedron.bVector)
+	assert.Equal(t, [][]int{{0, 1}}, polyhedron.A())
+	assert.Equal(t, []int{1}, polyhedron.B())
+}
+
+func Test_RemoveRows_shouldRemoveRowsAndBiases(t *testing.T) {
+	polyhedron := NewPolyhedron(
+		[][]int{
+			{1, 0},
+			{0, 1},
+			{1, 1},
+		},
+		[]int{1, 2, 3},
+	)
+
+	polyhedron.RemoveRows(0, 2)
+	assert.Equal(t, [][]int{{0, 1}}, polyhedron.A())
+	assert.Equal(t, []int{2}, polyhedron.B())
+}
+
+func Test_FindAssumedRow(t *testing.T) {
+	polyhedron := NewPolyhedron(
+		[][]int{
+			{-1, -1},
+			{0, 1},
+			{0, -1},
+		},
+		[]int{-1, 1, -1},
+	)
+
+	index, found := polyhedron.FindAssumedRow(1)
+	assert.True(t, found)
+	assert.Equal(t, 2, index)
+
+	_, found = polyhedron.FindAssumedRow(0)
+	assert.False(t, found)
+}
+
+func Test_PrecomputeSparseMatrix_givenChangeAfterwards_shouldRecalculate(t *testing.T) {
+	polyhedron := NewPolyhedron([][]int{{1, 0}}, []int{1})
+	polyhedron.PrecomputeSparseMatrix()
+
+	assert.Equal(t, []int{1}, polyhedron.SparseMatrix().Values())
+
+	polyhedron.Extend([]int{0, 2}, Bias(1))
+
+	assert.Equal(t, []int{1, 2}, polyhedron.SparseMatrix().Values())
+}
+
+func Test_NewSparsePolyhedron_shouldEqualDensePolyhedron(t *testing.T) {
+	dense := NewPolyhedron([][]int{{1, 0, 2}, {0, 0, 3}}, []int{1, 2})
+
+	sparse, err := NewSparsePolyhedron(
+		NewSparseMatrix([]int{1, 0, 0}, []int{2, 2, 0}, []int{3, 2, 1}, NewShape(2, 3)),
+		[]int{1, 2},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, dense.A(), sparse.A())
+	assert.Equal(t, dense.SparseMatrix(), sparse.SparseMatrix())
+}
+
+func Test_NewSparsePolyhedron_givenInvalidInput_shouldReturnError(t *testing.T) {
+	tests := []struct {
+		name    string
+		aMatrix SparseMatrix
+		bVector []int
+	}{
+		{
+			name:    "mismatched lengths",
+			aMatrix: NewSparseMatrix([]int{0}, []int{0, 1}, []int{1}, NewShape(1, 2)),
+			bVector: []int{1},
+		},
+		{
+			name:    "position outside of shape",
+			aMatrix: NewSparseMatrix([]int{0}, []int{2}, []int{1}, NewShape(1, 2)),
+			bVector: []int{1},
+		},
+		{
+			name:    "duplicate position",
+			aMatrix: NewSparseMatrix([]int{0, 0}, []int{1, 1}, []int{1, 1}, NewShape(1, 2)),
+			bVector: []int{1},
+		},
+		{
+			name:    "b vector length mismatch",
+			aMatrix: NewSparseMatrix([]int{0}, []int{1}, []int{1}, NewShape(1, 2)),
+			bVector: []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSparsePolyhedron(tt.aMatrix, tt.bVector)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_Copy_givenChangeToCopy_shouldNotChangeOriginal(t *testing.T) {
+	original := NewPolyhedron([][]int{{1, 0}}, []int{1})
+
+	copied := original.Copy()
+	copied.AddEmptyColumn()
+	copied.Extend([]int{0, 0, 1}, Bias(2))
+
+	assert.Equal(t, [][]int{{1, 0}}, original.A())
+	assert.Equal(t, []int{1}, original.B())
+	assert.Equal(t, [][]int{{1, 0, 0}, {0, 0, 1}}, copied.A())
 }
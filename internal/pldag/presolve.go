@@ -0,0 +1,363 @@
+package pldag
+
+import (
+	"maps"
+
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+const unfixed = -1
+
+// Result of simplifying a polyhedron before sending it to a solver.
+// Fixed variables and substituted variables are removed from
+// the polyhedron, and are given back by Restore.
+type Presolved struct {
+	polyhedron *Polyhedron
+	variables  []string
+	// Variables with a value forced by the constraints
+	fixed map[string]int
+	// Variables equivalent to, and replaced by, another variable
+	substituted map[string]string
+}
+
+func (p Presolved) Polyhedron() *Polyhedron {
+	return p.polyhedron
+}
+
+func (p Presolved) Variables() []string {
+	return p.variables
+}
+
+// Weights of substituted variables are added to the variable replacing them,
+// weights of fixed variables are dropped since they are constant.
+func (p Presolved) ReduceWeights(weights map[string]int) map[string]int {
+	reduced := make(map[string]int, len(p.variables))
+	for variable, weight := range weights {
+		if _, ok := p.fixed[variable]; ok {
+			continue
+		}
+
+		if representative, ok := p.substituted[variable]; ok {
+			variable = representative
+		}
+
+		reduced[variable] += weight
+	}
+
+	return reduced
+}
+
+// Adds fixed and substituted variables to a solution of the presolved polyhedron
+func (p Presolved) Restore(solution map[string]int) map[string]int {
+	restored := make(map[string]int, len(solution)+len(p.fixed)+len(p.substituted))
+	for variable, value := range solution {
+		restored[variable] = value
+	}
+
+	for variable, value := range p.fixed {
+		restored[variable] = value
+	}
+
+	for variable, representative := range p.substituted {
+		restored[variable] = solution[representative]
+	}
+
+	return restored
+}
+
+type presolver struct {
+	rows           []map[int]int
+	bVector        []int
+	removedRows    []bool
+	values         []int
+	representative []int
+}
+
+// Simplifies a polyhedron of boolean variables by
+//   - fixing variables forced by a single row,
+//   - removing rows that can never be violated,
+//   - substituting variables that imply each other.
+//
+// Returns puanerror.SolverFailed if the polyhedron is found infeasible.
+func Presolve(polyhedron *Polyhedron, variables []string) (Presolved, error) {
+	if polyhedron.nrOfColumns > len(variables) {
+		return Presolved{}, errors.Errorf(
+			"%w: polyhedron has %d columns but there are %d variables",
+			puanerror.InvalidArgument,
+			polyhedron.nrOfColumns,
+			len(variables),
+		)
+	}
+
+	p := newPresolver(polyhedron, len(variables))
+
+	for changed := true; changed; {
+		fixedAny, err := p.propagate()
+		if err != nil {
+			return Presolved{}, err
+		}
+
+		substitutedAny := p.substituteEquivalences()
+		changed = fixedAny || substitutedAny
+	}
+
+	return p.result(variables), nil
+}
+
+func newPresolver(polyhedron *Polyhedron, nrOfVariables int) *presolver {
+	rows := make([]map[int]int, len(polyhedron.rows))
+	for i, row := range polyhedron.rows {
+		rows[i] = make(map[int]int, len(row.columns))
+		for j, column := range row.columns {
+			rows[i][column] = row.values[j]
+		}
+	}
+
+	bVector := make([]int, len(polyhedron.bVector))
+	copy(bVector, polyhedron.bVector)
+
+	values := make([]int, nrOfVariables)
+	representative := make([]int, nrOfVariables)
+	for i := range values {
+		values[i] = unfixed
+		representative[i] = i
+	}
+
+	return &presolver{
+		rows:           rows,
+		bVector:        bVector,
+		removedRows:    make([]bool, len(rows)),
+		values:         values,
+		representative: representative,
+	}
+}
+
+// Fixes variables until no more can be fixed from single rows
+func (p *presolver) propagate() (bool, error) {
+	fixedAny := false
+	for changed := true; changed; {
+		changed = false
+		for i := range p.rows {
+			if p.removedRows[i] {
+				continue
+			}
+
+			fixed, err := p.propagateRow(i)
+			if err != nil {
+				return false, err
+			}
+
+			changed = changed || fixed
+		}
+		fixedAny = fixedAny || changed
+	}
+
+	return fixedAny, nil
+}
+
+func (p *presolver) propagateRow(i int) (bool, error) {
+	minActivity, maxActivity := p.activity(i)
+	bias := p.bVector[i]
+
+	if minActivity > bias {
+		return false, errors.Errorf(
+			"%w: presolve found row %d infeasible",
+			puanerror.SolverFailed,
+			i,
+		)
+	}
+
+	if maxActivity <= bias {
+		p.removedRows[i] = true
+		return false, nil
+	}
+
+	fixed := false
+	for column, value := range p.rows[i] {
+		if p.values[column] != unfixed {
+			continue
+		}
+
+		if value > 0 && minActivity+value > bias {
+			p.values[column] = 0
+			fixed = true
+		}
+
+		if value < 0 && minActivity-value > bias {
+			p.values[column] = 1
+			fixed = true
+		}
+	}
+
+	return fixed, nil
+}
+
+func (p *presolver) activity(i int) (int, int) {
+	minActivity, maxActivity := 0, 0
+	for column, value := range p.rows[i] {
+		switch {
+		case p.values[column] != unfixed:
+			minActivity += value * p.values[column]
+			maxActivity += value * p.values[column]
+		case value < 0:
+			minActivity += value
+		default:
+			maxActivity += value
+		}
+	}
+
+	return minActivity, maxActivity
+}
+
+// Finds pairs of variables implying each other, i.e. x <= y and y <= x,
+// and replaces the one with the highest column by the other. All pairs
+// found in one pass over the rows are substituted together.
+func (p *presolver) substituteEquivalences() bool {
+	implications := make(map[[2]int]bool)
+	substitutedAny := false
+	for i := range p.rows {
+		if p.removedRows[i] {
+			continue
+		}
+
+		from, to, ok := p.implication(i)
+		if !ok {
+			continue
+		}
+
+		implications[[2]int{from, to}] = true
+		if !implications[[2]int{to, from}] {
+			continue
+		}
+
+		from, to = p.findRepresentative(from), p.findRepresentative(to)
+		if from != to {
+			p.representative[max(from, to)] = min(from, to)
+			substitutedAny = true
+		}
+	}
+
+	if substitutedAny {
+		p.substituteRepresentatives()
+	}
+
+	return substitutedAny
+}
+
+// Row of the form c*x - c*y <= b, with 0 <= b < c, means x implies y
+func (p *presolver) implication(i int) (int, int, bool) {
+	var columns []int
+	bias := p.bVector[i]
+	for column, value := range p.rows[i] {
+		if p.values[column] != unfixed {
+			bias -= value * p.values[column]
+			continue
+		}
+		columns = append(columns, column)
+	}
+
+	if len(columns) != 2 {
+		return 0, 0, false
+	}
+
+	first, second := columns[0], columns[1]
+	firstValue, secondValue := p.rows[i][first], p.rows[i][second]
+	if firstValue+secondValue != 0 || bias < 0 || bias >= abs(firstValue) {
+		return 0, 0, false
+	}
+
+	if firstValue > 0 {
+		return first, second, true
+	}
+
+	return second, first, true
+}
+
+// Replaces substituted variables in the rows by their representatives
+func (p *presolver) substituteRepresentatives() {
+	for i, row := range p.rows {
+		if p.removedRows[i] {
+			continue
+		}
+
+		replaced := make(map[int]int, len(row))
+		for column, value := range row {
+			replaced[p.findRepresentative(column)] += value
+		}
+
+		maps.DeleteFunc(replaced, func(_ int, value int) bool {
+			return value == 0
+		})
+		p.rows[i] = replaced
+	}
+}
+
+func (p *presolver) findRepresentative(variable int) int {
+	for p.representative[variable] != variable {
+		variable = p.representative[variable]
+	}
+
+	return variable
+}
+
+func (p *presolver) result(variables []string) Presolved {
+	fixed := make(map[string]int)
+	substituted := make(map[string]string)
+	columnIndices := make(map[int]int)
+	var remaining []string
+
+	for i, variable := range variables {
+		representative := p.findRepresentative(i)
+		switch {
+		case p.values[representative] != unfixed:
+			fixed[variable] = p.values[representative]
+		case representative != i:
+			substituted[variable] = variables[representative]
+		default:
+			columnIndices[i] = len(remaining)
+			remaining = append(remaining, variable)
+		}
+	}
+
+	polyhedron := NewPolyhedron(nil, nil)
+	for range remaining {
+		polyhedron.AddEmptyColumn()
+	}
+
+	for i, row := range p.rows {
+		if p.removedRows[i] {
+			continue
+		}
+
+		var columns []int
+		var values []int
+		bias := p.bVector[i]
+		for column, value := range row {
+			if p.values[column] != unfixed {
+				bias -= value * p.values[column]
+				continue
+			}
+			columns = append(columns, columnIndices[column])
+			values = append(values, value)
+		}
+
+		polyhedron.ExtendSparse(NewSparseRow(columns, values), Bias(bias))
+	}
+
+	return Presolved{
+		polyhedron:  polyhedron,
+		variables:   remaining,
+		fixed:       fixed,
+		substituted: substituted,
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
@@ -0,0 +1,126 @@
+package pldag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+func Test_Presolve_givenForcedVariables_shouldFixThemAndRemoveRows(t *testing.T) {
+	// x is assumed, x + y <= 1 forces y to 0
+	polyhedron := NewPolyhedron(
+		[][]int{
+			{-1, 0},
+			{1, 1},
+		},
+		[]int{-1, 1},
+	)
+
+	presolved, err := Presolve(polyhedron, []string{"x", "y"})
+
+	require.NoError(t, err)
+	assert.Empty(t, presolved.Variables())
+	assert.True(t, presolved.Polyhedron().IsEmpty())
+	assert.Equal(t, map[string]int{"x": 1, "y": 0}, presolved.Restore(map[string]int{}))
+}
+
+func Test_Presolve_givenEquivalentVariables_shouldSubstitute(t *testing.T) {
+	// x <= y, y <= x and x + z <= 1
+	polyhedron := NewPolyhedron(
+		[][]int{
+			{1, -1, 0},
+			{-1, 1, 0},
+			{1, 0, 1},
+		},
+		[]int{0, 0, 1},
+	)
+
+	presolved, err := Presolve(polyhedron, []string{"x", "y", "z"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x", "z"}, presolved.Variables())
+	assert.Equal(t, [][]int{{1, 1}}, presolved.Polyhedron().A())
+	assert.Equal(t, []int{1}, presolved.Polyhedron().B())
+	assert.Equal(
+		t,
+		map[string]int{"x": 3, "z": 1},
+		presolved.ReduceWeights(map[string]int{"x": 1, "y": 2, "z": 1}),
+	)
+	assert.Equal(
+		t,
+		map[string]int{"x": 1, "y": 1, "z": 0},
+		presolved.Restore(map[string]int{"x": 1, "z": 0}),
+	)
+}
+
+func Test_presolver_substituteEquivalences_givenManyPairs_shouldSubstituteAllInOnePass(
+	t *testing.T,
+) {
+	// x <=> y, y <=> z and u <=> w
+	polyhedron := NewPolyhedron(
+		[][]int{
+			{1, -1, 0, 0, 0},
+			{-1, 1, 0, 0, 0},
+			{0, 1, -1, 0, 0},
+			{0, -1, 1, 0, 0},
+			{0, 0, 0, 1, -1},
+			{0, 0, 0, -1, 1},
+		},
+		[]int{0, 0, 0, 0, 0, 0},
+	)
+	p := newPresolver(polyhedron, 5)
+
+	substituted := p.substituteEquivalences()
+
+	assert.True(t, substituted)
+	assert.Equal(t, []int{0, 0, 0, 3, 3}, []int{
+		p.findRepresentative(0),
+		p.findRepresentative(1),
+		p.findRepresentative(2),
+		p.findRepresentative(3),
+		p.findRepresentative(4),
+	})
+	assert.False(t, p.substituteEquivalences())
+}
+
+func Test_Presolve_givenRedundantRow_shouldRemoveRow(t *testing.T) {
+	polyhedron := NewPolyhedron(
+		[][]int{
+			{1, 1},
+			{1, 1},
+		},
+		[]int{2, 1},
+	)
+
+	presolved, err := Presolve(polyhedron, []string{"x", "y"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x", "y"}, presolved.Variables())
+	assert.Equal(t, [][]int{{1, 1}}, presolved.Polyhedron().A())
+	assert.Equal(t, []int{1}, presolved.Polyhedron().B())
+}
+
+func Test_Presolve_givenInfeasiblePolyhedron_shouldReturnSolverFailed(t *testing.T) {
+	polyhedron := NewPolyhedron(
+		[][]int{
+			{-1},
+			{1},
+		},
+		[]int{-1, 0},
+	)
+
+	_, err := Presolve(polyhedron, []string{"x"})
+
+	assert.ErrorIs(t, err, puanerror.SolverFailed)
+}
+
+func Test_Presolve_givenMoreColumnsThanVariables_shouldReturnError(t *testing.T) {
+	polyhedron := NewPolyhedron([][]int{{1, 1}}, []int{1})
+
+	_, err := Presolve(polyhedron, []string{"x"})
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
@@ -1,5 +1,11 @@
 package pldag
 
+import (
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
 type SparseMatrix struct {
 	rows    []int
 	columns []int
@@ -50,3 +56,41 @@ func (s Shape) NrOfRows() int {
 func (s Shape) NrOfColumns() int {
 	return s.nrOfColumns
 }
+
+func (s SparseMatrix) validate() error {
+	if len(s.rows) != len(s.columns) || len(s.rows) != len(s.values) {
+		return errors.Errorf(
+			"%w: rows, columns and values must have the same length, got %d, %d and %d",
+			puanerror.InvalidArgument,
+			len(s.rows),
+			len(s.columns),
+			len(s.values),
+		)
+	}
+
+	seen := make(map[[2]int]struct{}, len(s.rows))
+	for i := range s.rows {
+		row, column := s.rows[i], s.columns[i]
+		if row < 0 || row >= s.shape.nrOfRows || column < 0 || column >= s.shape.nrOfColumns {
+			return errors.Errorf(
+				"%w: position (%d, %d) is outside of shape %v",
+				puanerror.InvalidArgument,
+				row,
+				column,
+				s.shape,
+			)
+		}
+
+		if _, exists := seen[[2]int{row, column}]; exists {
+			return errors.Errorf(
+				"%w: duplicate value at position (%d, %d)",
+				puanerror.InvalidArgument,
+				row,
+				column,
+			)
+		}
+		seen[[2]int{row, column}] = struct{}{}
+	}
+
+	return nil
+}
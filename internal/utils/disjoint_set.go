@@ -0,0 +1,46 @@
+package utils
+
+// Union-find of elements, used to find connected components
+type DisjointSet[T comparable] struct {
+	parents map[T]T
+}
+
+func NewDisjointSet[T comparable]() *DisjointSet[T] {
+	return &DisjointSet[T]{
+		parents: make(map[T]T),
+	}
+}
+
+// Representative of the set of the element.
+// Elements not yet added form a set of their own.
+func (d *DisjointSet[T]) Find(element T) T {
+	parent, ok := d.parents[element]
+	if !ok || parent == element {
+		return element
+	}
+
+	root := d.Find(parent)
+	d.parents[element] = root
+
+	return root
+}
+
+// Merges the sets of the elements
+func (d *DisjointSet[T]) Union(elements ...T) {
+	if len(elements) == 0 {
+		return
+	}
+
+	root := d.Find(elements[0])
+	d.parents[root] = root
+	for _, element := range elements[1:] {
+		elementRoot := d.Find(element)
+		if elementRoot != root {
+			d.parents[elementRoot] = root
+		}
+	}
+}
+
+func (d *DisjointSet[T]) Connected(a, b T) bool {
+	return d.Find(a) == d.Find(b)
+}
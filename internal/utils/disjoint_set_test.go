@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DisjointSet_Union_shouldConnectTransitively(t *testing.T) {
+	set := NewDisjointSet[string]()
+
+	set.Union("a", "b")
+	set.Union("c", "d")
+	set.Union("b", "c")
+	set.Union("e")
+
+	assert.True(t, set.Connected("a", "d"))
+	assert.False(t, set.Connected("a", "e"))
+	assert.False(t, set.Connected("a", "f"))
+	assert.True(t, set.Connected("f", "f"))
+}
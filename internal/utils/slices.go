@@ -24,6 +24,24 @@ func Without[T comparable](sliceA []T, sliceB []T) []T {
 	return result
 }
 
+// Elements of sliceA also in sliceB, in the order of sliceA
+func Intersect[T comparable](sliceA []T, sliceB []T) []T {
+	var result []T
+	includeMap := make(map[T]struct{}, len(sliceB))
+
+	for _, item := range sliceB {
+		includeMap[item] = struct{}{}
+	}
+
+	for _, item := range sliceA {
+		if _, found := includeMap[item]; found {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
 func ContainsDuplicates[T comparable](elements []T) bool {
 	seen := make(map[T]any)
 	for _, e := range elements {
@@ -33,6 +33,16 @@ func Test_Without_nilInput_shouldReturnEmptySlice(t *testing.T) {
 	assert.Equal(t, want, actual)
 }
 
+func Test_Intersect(t *testing.T) {
+	sliceA := []int{1, 2, 3, 4, 5}
+	sliceB := []int{4, 2, 6}
+	want := []int{2, 4}
+
+	actual := Intersect(sliceA, sliceB)
+
+	assert.Equal(t, want, actual)
+}
+
 func Test_ContainsDuplicates_shouldReturnTrue(t *testing.T) {
 	slice := []string{"a", "b", "c", "a"}
 	actual := ContainsDuplicates(slice)
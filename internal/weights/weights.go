@@ -13,6 +13,18 @@ const NOT_SELECTED_WEIGHT = -2
 // WEIGHTS_SATURATION_LIMIT is set to 2^32.
 const WEIGHTS_SATURATION_LIMIT = 4294967296
 
+// Number of selections per tier in CalculateTiers.
+// The weights of a tier sum to at most 2^32 - 1.
+const SELECTIONS_PER_TIER = 32
+
+const (
+	PERIODS_BEFORE_PREFERREDS PeriodPriority = "PERIODS_BEFORE_PREFERREDS"
+	PREFERREDS_BEFORE_PERIODS PeriodPriority = "PREFERREDS_BEFORE_PERIODS"
+)
+
+// Which of periods and preferreds outweighs the other
+type PeriodPriority string
+
 type Weights map[string]int
 
 func (w Weights) concat(weightsToConcat Weights) Weights {
@@ -63,47 +75,196 @@ func (w Weights) WeightsTooLarge() bool {
 	return tooLarge
 }
 
+// Period IDs are given in order of priority, the first being the most wanted.
+// Choosing a period outweighs preferreds, see CalculateWithPeriodPriority.
 func Calculate(
 	selectableIDs []string,
 	selections Selections,
 	preferredIDs []string,
 	periodIDs []string,
 ) (Weights, error) {
+	return CalculateWithPeriodPriority(
+		selectableIDs,
+		selections,
+		preferredIDs,
+		periodIDs,
+		PERIODS_BEFORE_PREFERREDS,
+	)
+}
+
+// As Calculate, where PREFERREDS_BEFORE_PERIODS lets preferreds outweigh
+// periods, e.g. a later period is chosen if it satisfies more preferreds.
+func CalculateWithPeriodPriority(
+	selectableIDs []string,
+	selections Selections,
+	preferredIDs []string,
+	periodIDs []string,
+	priority PeriodPriority,
+) (Weights, error) {
+	base, err := calculateBaseWeights(
+		selectableIDs,
+		selections,
+		preferredIDs,
+		periodIDs,
+		priority == PREFERREDS_BEFORE_PERIODS,
+	)
+	if err != nil {
+		return Weights{}, err
+	}
+
+	selectedWeights, err := calculateSelectedWeights(
+		selections,
+		base.notSelected.sum(),
+		base.preferred.sum(),
+		base.period.maxWeight(),
+	)
+	if err != nil {
+		return Weights{}, err
+	}
+
+	return base.weights().concat(selectedWeights), nil
+}
+
+// Lexicographic alternative to CalculateWithPeriodPriority, for when the
+// weights are too large. Returns tiers of weights, the most prioritised first,
+// where each tier outranks all later tiers together. Solving the tiers in
+// order, keeping the objective value of earlier tiers, gives the same solution
+// as the weights of CalculateWithPeriodPriority. The last tier weights not selected, preferred and
+// period variables, and fails if too large on its own.
+func CalculateTiers(
+	selectableIDs []string,
+	selections Selections,
+	preferredIDs []string,
+	periodIDs []string,
+	priority PeriodPriority,
+) ([]Weights, error) {
+	base, err := calculateBaseWeights(
+		selectableIDs,
+		selections,
+		preferredIDs,
+		periodIDs,
+		priority == PREFERREDS_BEFORE_PERIODS,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	baseWeights := base.weights()
+	if baseWeights.WeightsTooLarge() {
+		return nil, errors.New("weights of variables without selections are too large")
+	}
+
+	var tiers []Weights
+	for end := len(selections); end > 0; end -= SELECTIONS_PER_TIER {
+		start := max(end-SELECTIONS_PER_TIER, 0)
+
+		tier, err := calculateSelectedWeights(selections[start:end], 0, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		tiers = append(tiers, tier)
+	}
+
+	return append(tiers, baseWeights), nil
+}
+
+// Weights of Calculate that do not depend on the order of selections
+type baseWeights struct {
+	notSelected Weights
+	preferred   Weights
+	period      Weights
+}
+
+func (b baseWeights) weights() Weights {
+	return b.notSelected.
+		concat(b.preferred).
+		concat(b.period)
+}
+
+func calculateBaseWeights(
+	selectableIDs []string,
+	selections Selections,
+	preferredIDs []string,
+	periodIDs []string,
+	preferredsBeforePeriods bool,
+) (baseWeights, error) {
 	notSelectedIDs := utils.Without(selectableIDs, selections.ids())
 
 	notSelectedWeights := calculatedNotSelectedWeights(notSelectedIDs)
 	notSelectedSum := notSelectedWeights.sum()
 
+	var preferredWeights Weights
+	var periodWeights Weights
+	var err error
+	if preferredsBeforePeriods {
+		preferredWeights, periodWeights, err = calculatePreferredBeforePeriodWeights(
+			preferredIDs,
+			periodIDs,
+			notSelectedSum,
+		)
+	} else {
+		preferredWeights, periodWeights, err = calculatePeriodBeforePreferredWeights(
+			preferredIDs,
+			periodIDs,
+			notSelectedSum,
+		)
+	}
+	if err != nil {
+		return baseWeights{}, err
+	}
+
+	return baseWeights{
+		notSelected: notSelectedWeights,
+		preferred:   preferredWeights,
+		period:      periodWeights,
+	}, nil
+}
+
+func calculatePeriodBeforePreferredWeights(
+	preferredIDs []string,
+	periodIDs []string,
+	notSelectedSum int,
+) (Weights, Weights, error) {
 	preferredWeights := calculatePreferredWeights(preferredIDs, notSelectedSum)
-	preferredSum := preferredWeights.sum()
 
 	periodWeights, err := calculatePeriodWeights(
 		periodIDs,
 		notSelectedSum,
-		preferredSum,
+		preferredWeights.sum(),
 	)
 	if err != nil {
-		return Weights{}, err
+		return Weights{}, Weights{}, err
 	}
 
-	maxPeriodWeight := periodWeights.maxWeight()
+	return preferredWeights, periodWeights, nil
+}
 
-	selectedWeights, err := calculateSelectedWeights(
-		selections,
-		notSelectedSum,
-		preferredSum,
-		maxPeriodWeight,
-	)
+func calculatePreferredBeforePeriodWeights(
+	preferredIDs []string,
+	periodIDs []string,
+	notSelectedSum int,
+) (Weights, Weights, error) {
+	periodWeights, err := calculatePeriodWeights(periodIDs, notSelectedSum, 0)
 	if err != nil {
-		return Weights{}, err
+		return Weights{}, Weights{}, err
+	}
+
+	// Only one period is chosen, so a preferred outweighing
+	// all periods together outweighs any choice of period
+	periodAbsSum, err := periodWeights.absSum()
+	if err != nil {
+		return Weights{}, Weights{}, err
 	}
 
-	weights := notSelectedWeights.
-		concat(selectedWeights).
-		concat(preferredWeights).
-		concat(periodWeights)
+	threshold, err := absSum(notSelectedSum, periodAbsSum, 1)
+	if err != nil {
+		return Weights{}, Weights{}, err
+	}
+
+	preferredWeights := calculatePreferredWeights(preferredIDs, -threshold)
 
-	return weights, nil
+	return preferredWeights, periodWeights, nil
 }
 
 func calculatedNotSelectedWeights(selectableIDs []string) Weights {
@@ -211,3 +372,19 @@ func absSum(terms ...int) (int, error) {
 
 	return sum, nil
 }
+
+// Copy of the weights where the variable outweighs all other variables
+// together, i.e. the variable is set whenever the constraints allow it.
+func (w Weights) Force(id string) (Weights, error) {
+	sum, err := w.absSum()
+	if err != nil {
+		return nil, err
+	}
+
+	forced, err := absSum(sum, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.concat(Weights{id: forced}), nil
+}
@@ -1,6 +1,7 @@
 package weights
 
 import (
+	"fmt"
 	"math"
 	"testing"
 
@@ -231,6 +232,48 @@ func Test_calculateWeights(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func Test_calculateWeights_givenPreferredsBeforePeriods_shouldOutweighPeriods(t *testing.T) {
+	primitives := []string{"a", "b"}
+	preferredIDs := []string{"e"}
+	periodIDs := []string{"p0", "p1"}
+
+	actual, err := CalculateWithPeriodPriority(
+		primitives,
+		nil,
+		preferredIDs,
+		periodIDs,
+		PREFERREDS_BEFORE_PERIODS,
+	)
+
+	assert.NoError(t, err)
+	expected := Weights{
+		"a":  -2,
+		"b":  -2,
+		"e":  -9,
+		"p0": 0,
+		"p1": -5,
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func Test_calculateWeights_givenPeriodsBeforePreferreds_shouldOutweighPreferreds(t *testing.T) {
+	primitives := []string{"a", "b"}
+	preferredIDs := []string{"e"}
+	periodIDs := []string{"p0", "p1"}
+
+	actual, err := Calculate(primitives, nil, preferredIDs, periodIDs)
+
+	assert.NoError(t, err)
+	expected := Weights{
+		"a":  -2,
+		"b":  -2,
+		"e":  -3,
+		"p0": 0,
+		"p1": -8,
+	}
+	assert.Equal(t, expected, actual)
+}
+
 func Test_abs(t *testing.T) {
 	theories := []struct {
 		input    int
@@ -387,3 +430,75 @@ func Test_calculatePeriodWeights(t *testing.T) {
 		})
 	}
 }
+
+func Test_Weights_Force_shouldOutweighAllOtherWeights(t *testing.T) {
+	w := Weights{
+		"x": 3,
+		"y": -4,
+		"z": 2,
+	}
+
+	actual, err := w.Force("z")
+
+	assert.NoError(t, err)
+	assert.Equal(t, Weights{"x": 3, "y": -4, "z": 10}, actual)
+	assert.Equal(t, 2, w["z"])
+}
+
+func Test_Weights_Force_givenTooLargeWeights_shouldReturnError(t *testing.T) {
+	w := Weights{
+		"x": math.MaxInt,
+	}
+
+	_, err := w.Force("y")
+
+	assert.Error(t, err)
+}
+
+func Test_CalculateTiers_givenManySelections_shouldSplitIntoTiersWithinLimit(t *testing.T) {
+	var selections Selections
+	for i := range 40 {
+		selection, _ := NewSelection(fmt.Sprintf("s%d", i), ADD)
+		selections = append(selections, selection)
+	}
+
+	tiers, err := CalculateTiers(
+		[]string{"x"},
+		selections,
+		nil,
+		nil,
+		PERIODS_BEFORE_PREFERREDS,
+	)
+
+	assert.NoError(t, err)
+	assert.Len(t, tiers, 3)
+	assert.Len(t, tiers[0], SELECTIONS_PER_TIER)
+	assert.Equal(t, 1<<31, tiers[0]["s39"])
+	assert.Equal(t, 1, tiers[0]["s8"])
+	assert.Len(t, tiers[1], 8)
+	assert.Equal(t, 1<<7, tiers[1]["s7"])
+	assert.Equal(t, Weights{"x": NOT_SELECTED_WEIGHT}, tiers[2])
+	for _, tier := range tiers {
+		assert.False(t, tier.WeightsTooLarge())
+	}
+}
+
+func Test_CalculateTiers_givenRemove_shouldNegate(t *testing.T) {
+	add, _ := NewSelection("x", ADD)
+	remove, _ := NewSelection("y", REMOVE)
+
+	tiers, err := CalculateTiers(
+		[]string{"x", "y", "w"},
+		Selections{add, remove},
+		nil,
+		nil,
+		PERIODS_BEFORE_PREFERREDS,
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]Weights{{"x": 1, "y": -2}, {"w": NOT_SELECTED_WEIGHT}},
+		tiers,
+	)
+}
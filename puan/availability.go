@@ -0,0 +1,104 @@
+package puan
+
+import (
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+// Result of searching for a period where all selections can be honoured
+type AvailabilityEnvelope struct {
+	period            *Period
+	solution          Solution
+	infeasiblePeriods []Period
+}
+
+func (e AvailabilityEnvelope) IsAvailable() bool {
+	return e.period != nil
+}
+
+// The earliest or latest period where all selections are honoured.
+// Returns puanerror.NotFound if the selections are not available in any period.
+func (e AvailabilityEnvelope) Period() (Period, error) {
+	if e.period == nil {
+		return Period{}, errors.Errorf(
+			"%w: selections are not available in any period",
+			puanerror.NotFound,
+		)
+	}
+
+	return *e.period, nil
+}
+
+// Solution in the found period, nil if not available
+func (e AvailabilityEnvelope) Solution() Solution {
+	return e.solution
+}
+
+// Periods where the selections cannot all be honoured, sorted by time
+func (e AvailabilityEnvelope) InfeasiblePeriods() []Period {
+	return e.infeasiblePeriods
+}
+
+// Finds the earliest period, not passed at the from time of the query,
+// where all selections can be honoured.
+func (c *SolutionCreator) FindEarliestAvailability(
+	query SolutionQuery,
+) (AvailabilityEnvelope, error) {
+	return c.findAvailability(query, false)
+}
+
+// Finds the latest period, not later than the to time of the query,
+// where all selections can be honoured.
+func (c *SolutionCreator) FindLatestAvailability(
+	query SolutionQuery,
+) (AvailabilityEnvelope, error) {
+	return c.findAvailability(query, true)
+}
+
+func (c *SolutionCreator) findAvailability(
+	query SolutionQuery,
+	latest bool,
+) (AvailabilityEnvelope, error) {
+	query, err := c.prepareQuery(query)
+	if err != nil {
+		return AvailabilityEnvelope{}, err
+	}
+
+	if query.ruleset.timeDisabled() {
+		return AvailabilityEnvelope{}, errors.Errorf(
+			"%w: time support not enabled for ruleset",
+			puanerror.InvalidOperation,
+		)
+	}
+
+	entries, err := c.calculateTimeline(query)
+	if err != nil {
+		err = c.explainSolveError(err, query)
+		return AvailabilityEnvelope{}, err
+	}
+
+	honoured := make(map[Period]Solution, len(entries))
+	for _, entry := range entries {
+		if query.selections.areHonouredBy(entry.solution) {
+			honoured[entry.period] = entry.solution
+		}
+	}
+
+	envelope := AvailabilityEnvelope{}
+	periods := query.ruleset.periodVariables.between(query.from, query.to).periods()
+	for _, period := range periods {
+		solution, ok := honoured[period]
+		if !ok {
+			envelope.infeasiblePeriods = append(envelope.infeasiblePeriods, period)
+			continue
+		}
+
+		if envelope.period == nil || latest {
+			envelope.period = &period
+			envelope.solution = solution
+		}
+	}
+
+	return envelope, nil
+}
@@ -0,0 +1,144 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+func Test_SolutionCreator_FindEarliestAvailability_shouldReturnFirstHonouringPeriod(
+	t *testing.T,
+) {
+	// x is only available between the 10th and the 20th
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y")
+	notX, _ := creator.SetNot("x")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-10T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-20T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	ruleset, _ := creator.Create()
+
+	selections := Selections{
+		NewSelectionBuilder("x").Build(),
+		NewSelectionBuilder("y").Build(),
+	}
+
+	envelope, err := NewSolutionCreator(bruteForceSolverClient{}).FindEarliestAvailability(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(selections).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.True(t, envelope.IsAvailable())
+	period, err := envelope.Period()
+	require.NoError(t, err)
+	assert.Equal(t, newTestPeriod("2024-01-10T00:00:00Z", "2024-01-20T00:00:00Z"), period)
+	assert.Equal(t, Solution{"x": 1, "y": 1}, envelope.Solution().Extract("x", "y"))
+	assert.Equal(
+		t,
+		[]Period{
+			newTestPeriod("2024-01-01T00:00:00Z", "2024-01-10T00:00:00Z"),
+			newTestPeriod("2024-01-20T00:00:00Z", "2024-01-31T00:00:00Z"),
+		},
+		envelope.InfeasiblePeriods(),
+	)
+}
+
+func Test_SolutionCreator_FindLatestAvailability_shouldReturnLastHonouringPeriod(
+	t *testing.T,
+) {
+	// x is only available between the 10th and the 20th
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y")
+	notX, _ := creator.SetNot("x")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-10T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-20T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	ruleset, _ := creator.Create()
+
+	selections := Selections{NewSelectionBuilder("y").Build()}
+
+	envelope, err := NewSolutionCreator(bruteForceSolverClient{}).FindLatestAvailability(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(selections).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	period, err := envelope.Period()
+	require.NoError(t, err)
+	assert.Equal(t, newTestPeriod("2024-01-20T00:00:00Z", "2024-01-31T00:00:00Z"), period)
+	assert.Empty(t, envelope.InfeasiblePeriods())
+}
+
+func Test_SolutionCreator_FindEarliestAvailability_givenFromAfterAvailability_shouldReturnNotFound(
+	t *testing.T,
+) {
+	// x is only available between the 10th and the 20th
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y")
+	notX, _ := creator.SetNot("x")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-10T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-20T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	ruleset, _ := creator.Create()
+
+	from := newTestTime("2024-01-25T00:00:00Z")
+
+	envelope, err := NewSolutionCreator(bruteForceSolverClient{}).FindEarliestAvailability(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("x").Build()}).
+			WithFrom(&from).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.False(t, envelope.IsAvailable())
+	_, err = envelope.Period()
+	assert.ErrorIs(t, err, puanerror.NotFound)
+	assert.Equal(
+		t,
+		[]Period{newTestPeriod("2024-01-20T00:00:00Z", "2024-01-31T00:00:00Z")},
+		envelope.InfeasiblePeriods(),
+	)
+}
@@ -0,0 +1,81 @@
+package puan
+
+import (
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+// Validates queries before solving. With composite validation enabled,
+// composite selections are checked against the ruleset.
+func (c *SolutionCreator) validate(query SolutionQuery) error {
+	err := query.validate()
+	if err != nil {
+		return err
+	}
+
+	if !c.validateComposites {
+		return nil
+	}
+
+	return c.validateCompositeSelections(query)
+}
+
+// Checks that the variables of each composite selection of the query
+// can be selected together under the rules of the ruleset, within the
+// periods allowed by the query. Returns puanerror.InvalidArgument
+// for the first composite selection that cannot be met.
+func (c *SolutionCreator) ValidateCompositeSelections(query SolutionQuery) error {
+	err := query.validate()
+	if err != nil {
+		return err
+	}
+
+	return c.validateCompositeSelections(query)
+}
+
+// Validates the composite selections of an already validated query
+func (c *SolutionCreator) validateCompositeSelections(query SolutionQuery) error {
+	validated := make(map[string]bool)
+	for _, selection := range query.selections {
+		if !selection.IsComposite() || selection.action == REMOVE {
+			continue
+		}
+
+		hash := selection.Hash()
+		if validated[hash] {
+			continue
+		}
+		validated[hash] = true
+
+		consistent, err := c.isCompositeSelectionConsistent(query, selection)
+		if err != nil {
+			return err
+		}
+
+		if !consistent {
+			return errors.Errorf(
+				"%w: composite selection cannot be met by the ruleset: %v",
+				puanerror.InvalidArgument,
+				selection,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (c *SolutionCreator) isCompositeSelectionConsistent(
+	query SolutionQuery,
+	selection Selection,
+) (bool, error) {
+	composite := selection.withAction(ADD)
+	composite.hard = true
+
+	ruleset, err := query.ruleset.modifyForQuery(Selections{composite}, query.from, query.to)
+	if err != nil {
+		return false, err
+	}
+
+	return c.isFeasible(ruleset)
+}
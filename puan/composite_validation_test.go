@@ -0,0 +1,140 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+func Test_SolutionCreator_ValidateCompositeSelections_givenInconsistentComposite_shouldReturnError(
+	t *testing.T,
+) {
+	// a and b are mutually exclusive, c requires a and x excludes y
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "a", "b", "c")
+	oneOrNone, _ := creator.SetOneOrNone("a", "b")
+	imply, _ := creator.SetImply("c", "a")
+	exclude, _ := creator.SetOneOrNone("x", "y")
+	and, _ := creator.SetAnd(oneOrNone, imply, exclude)
+	_ = creator.Assume(and)
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	err := solutionCreator.ValidateCompositeSelections(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").
+					WithSubSelectionID("a").
+					WithSubSelectionID("b").
+					Build(),
+			}).
+			Build(),
+	)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_SolutionCreator_Create_givenCompositeValidation_shouldRejectInconsistentComposite(
+	t *testing.T,
+) {
+	// a and b are mutually exclusive, c requires a and x excludes y
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "a", "b", "c")
+	oneOrNone, _ := creator.SetOneOrNone("a", "b")
+	imply, _ := creator.SetImply("c", "a")
+	exclude, _ := creator.SetOneOrNone("x", "y")
+	and, _ := creator.SetAnd(oneOrNone, imply, exclude)
+	_ = creator.Assume(and)
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{}).
+		WithCompositeValidation(true)
+
+	_, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").
+					WithSubSelectionID("c").
+					WithCompositeKind(EXACTLY).
+					WithSiblings("a").
+					Build(),
+			}).
+			Build(),
+	)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_SolutionCreator_Create_givenAnyOf_shouldSelectAllowedSubSelection(t *testing.T) {
+	// a and b are mutually exclusive, c requires a and x excludes y
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "a", "b", "c")
+	oneOrNone, _ := creator.SetOneOrNone("a", "b")
+	imply, _ := creator.SetImply("c", "a")
+	exclude, _ := creator.SetOneOrNone("x", "y")
+	and, _ := creator.SetAnd(oneOrNone, imply, exclude)
+	_ = creator.Assume(and)
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{}).
+		WithCompositeValidation(true)
+
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("b").Build(),
+				NewSelectionBuilder("x").
+					WithSubSelectionID("a").
+					WithSubSelectionID("b").
+					WithCompositeKind(ANY_OF).
+					Build(),
+			}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, envelope.Solution()["x"])
+	assert.Equal(t, 0, envelope.Solution()["a"])
+	assert.Equal(t, 1, envelope.Solution()["b"])
+}
+
+func Test_SolutionCreator_Create_givenNestedComposite_shouldSelectAllVariables(t *testing.T) {
+	// a and b are mutually exclusive, c requires a and x excludes y
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "a", "b", "c")
+	oneOrNone, _ := creator.SetOneOrNone("a", "b")
+	imply, _ := creator.SetImply("c", "a")
+	exclude, _ := creator.SetOneOrNone("x", "y")
+	and, _ := creator.SetAnd(oneOrNone, imply, exclude)
+	_ = creator.Assume(and)
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("b").Build(),
+				NewSelectionBuilder("x").
+					WithSubSelection(NewSelectionBuilder("c").WithSubSelectionID("a").Build()).
+					Build(),
+			}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		Solution{"x": 1, "y": 0, "a": 1, "b": 0, "c": 1},
+		envelope.Solution(),
+	)
+	assert.Equal(t, HONOURED, envelope.SelectionReports()[1].Status())
+}
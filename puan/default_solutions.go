@@ -0,0 +1,58 @@
+package puan
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/utils"
+)
+
+// Maximum number of default solutions cached per solution creator,
+// one per ruleset, combination of allowed periods and period weighting,
+// and one per component of a ruleset
+const DefaultSolutionCacheCapacity = 128
+
+var lastRulesetCacheID atomic.Uint64
+
+func newRulesetCacheID() uint64 {
+	return lastRulesetCacheID.Add(1)
+}
+
+// Queries without selections on a ruleset as created,
+// whose solutions are cached in the solution creator
+func (query SolutionQuery) isDefault() bool {
+	return len(query.selections) == 0 && query.ruleset.cacheID != 0
+}
+
+// The default solution of a ruleset only depends on which periods are
+// forbidden by from and to, and on how the allowed periods are weighted
+func (query SolutionQuery) newDefaultSolutionKey() string {
+	forbidden := utils.Sorted(utils.Dedupe(
+		query.ruleset.findForbiddenPeriodIDs(query.from, query.to),
+	))
+	ordered := query.periodWeighting.orderPeriodIDs(query.ruleset.periodVariables)
+
+	return strconv.FormatUint(query.ruleset.cacheID, 10) + "|" +
+		strings.Join(forbidden, ",") + "|" +
+		strings.Join(ordered, ",") + "|" +
+		strconv.FormatBool(query.periodWeighting.preferredsFirst)
+}
+
+// Solves the query without selections once per key,
+// serving later queries from the cache of the solution creator
+func (c *SolutionCreator) findDefaultSolution(query SolutionQuery) (Solution, error) {
+	key := query.newDefaultSolutionKey()
+	if solution, ok := c.defaultSolutions.Get(key); ok {
+		return solution.copy(), nil
+	}
+
+	solution, err := c.calculateUncachedDependentSolution(query)
+	if err != nil {
+		return Solution{}, err
+	}
+
+	c.defaultSolutions.Add(key, solution.copy())
+
+	return solution, nil
+}
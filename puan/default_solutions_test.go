@@ -0,0 +1,167 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SolutionCreator_Create_givenNoSelections_shouldSolveOnce(t *testing.T) {
+	ruleset := newXorRuleset(t)
+
+	client := &countingSolverClient{}
+	solutionCreator := NewSolutionCreator(client)
+	query := NewSolutionQueryBuilder().WithRuleset(ruleset).Build()
+
+	first, err := solutionCreator.Create(query)
+	require.NoError(t, err)
+	calls := client.solveCalls
+
+	second, err := solutionCreator.Create(query)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Solution(), second.Solution())
+	assert.Equal(t, calls, client.solveCalls)
+}
+
+func Test_SolutionCreator_Create_givenOtherSolutionCreator_shouldNotShareCache(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	query := NewSolutionQueryBuilder().WithRuleset(ruleset).Build()
+	_, err := NewSolutionCreator(bruteForceSolverClient{}).Create(query)
+	require.NoError(t, err)
+
+	client := &countingSolverClient{}
+	_, err = NewSolutionCreator(client).Create(query)
+	require.NoError(t, err)
+
+	assert.Positive(t, client.solveCalls)
+}
+
+func Test_SolutionCreator_Create_givenChangedDefaultSolution_shouldNotChangeCache(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+	query := NewSolutionQueryBuilder().WithRuleset(ruleset).Build()
+
+	first, err := solutionCreator.Create(query)
+	require.NoError(t, err)
+	want := first.Solution().copy()
+	first.Solution()["x"] = 1 - first.Solution()["x"]
+
+	second, err := solutionCreator.Create(query)
+	require.NoError(t, err)
+
+	assert.Equal(t, want, second.Solution())
+}
+
+func Test_SolutionQuery_isDefault_givenSelections_shouldReturnFalse(t *testing.T) {
+	ruleset := newXorRuleset(t)
+
+	query := NewSolutionQueryBuilder().
+		WithRuleset(ruleset).
+		WithSelections(Selections{NewSelectionBuilder("x").Build()}).
+		Build()
+
+	assert.False(t, query.isDefault())
+}
+
+func Test_SolutionQuery_isDefault_givenChangedRuleset_shouldReturnFalse(t *testing.T) {
+	ruleset := newXorRuleset(t)
+
+	err := ruleset.assume("x")
+	require.NoError(t, err)
+
+	query := NewSolutionQueryBuilder().WithRuleset(ruleset).Build()
+
+	assert.False(t, query.isDefault())
+	assert.Nil(t, ruleset.components)
+}
+
+func Test_SolutionQuery_newDefaultSolutionKey_givenDifferentPeriods_shouldDiffer(
+	t *testing.T,
+) {
+	// x is only available between the 10th and the 20th
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y")
+	notX, _ := creator.SetNot("x")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-10T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-20T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	ruleset, _ := creator.Create()
+
+	early := newTestTime("2024-01-05T00:00:00Z")
+	late := newTestTime("2024-01-25T00:00:00Z")
+
+	fromEarly := NewSolutionQueryBuilder().WithRuleset(ruleset).WithFrom(&early).Build()
+	fromLate := NewSolutionQueryBuilder().WithRuleset(ruleset).WithFrom(&late).Build()
+	latest := NewSolutionQueryBuilder().
+		WithRuleset(ruleset).
+		WithFrom(&early).
+		WithPeriodDirection(LATEST).
+		Build()
+
+	assert.NotEqual(t, fromEarly.newDefaultSolutionKey(), fromLate.newDefaultSolutionKey())
+	assert.NotEqual(t, fromEarly.newDefaultSolutionKey(), latest.newDefaultSolutionKey())
+}
+
+func Test_SolutionCreator_Create_givenDifferentPeriods_shouldCacheEachDefault(
+	t *testing.T,
+) {
+	// x is only available between the 10th and the 20th
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y")
+	notX, _ := creator.SetNot("x")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-10T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-20T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+	early := newTestTime("2024-01-12T00:00:00Z")
+	late := newTestTime("2024-01-25T00:00:00Z")
+
+	fromEarly := NewSolutionQueryBuilder().WithRuleset(ruleset).WithFrom(&early).Build()
+	fromLate := NewSolutionQueryBuilder().WithRuleset(ruleset).WithFrom(&late).Build()
+
+	for range 2 {
+		earlyEnvelope, err := solutionCreator.Create(fromEarly)
+		require.NoError(t, err)
+		lateEnvelope, err := solutionCreator.Create(fromLate)
+		require.NoError(t, err)
+
+		earlyPeriod, err := ruleset.FindPeriodInSolution(earlyEnvelope.Solution())
+		require.NoError(t, err)
+		latePeriod, err := ruleset.FindPeriodInSolution(lateEnvelope.Solution())
+		require.NoError(t, err)
+
+		assert.NotEqual(t, earlyPeriod, latePeriod)
+	}
+}
@@ -0,0 +1,33 @@
+package puan
+
+import (
+	"github.com/ourstudio-se/puan-sdk-go/internal/gateway/inproc"
+)
+
+// Solves small queries exhaustively with the in-process solver.
+// Only intended for tests that cannot depend on the GLPK API.
+type bruteForceSolverClient struct{}
+
+func (c bruteForceSolverClient) Solve(query *SolverQuery) (Solution, error) {
+	return inproc.Solve(query.polyhedron, query.variables, query.weights)
+}
+
+func (c bruteForceSolverClient) SolveWithManyWeights(
+	query *MultiWeightSolverQuery,
+) ([]Solution, error) {
+	solutions, err := inproc.SolveWithManyWeights(
+		query.polyhedron,
+		query.variables,
+		query.weightGroups,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]Solution, len(solutions))
+	for i, solution := range solutions {
+		converted[i] = solution
+	}
+
+	return converted, nil
+}
@@ -0,0 +1,67 @@
+package puan
+
+import (
+	"slices"
+
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+// Whether any configuration meets the rules of the ruleset
+func (c *SolutionCreator) isFeasible(ruleset Ruleset) (bool, error) {
+	weights, err := newWeights(ruleset, nil, periodWeighting{})
+	if err != nil {
+		return false, err
+	}
+
+	solverQuery := NewSolverQuery(
+		ruleset.polyhedron,
+		ruleset.dependentVariables,
+		weights,
+	)
+
+	_, err = c.Solve(solverQuery)
+	if errors.Is(err, puanerror.SolverFailed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Deletion filter over the items, finding a minimal set that is
+// infeasible together. An item is dropped from the conflict if it
+// stays infeasible without it. Returns nil if it is infeasible
+// without any of the items.
+func findMinimalConflict[S ~[]T, T any](
+	items S,
+	isFeasible func(kept S) (bool, error),
+) (S, error) {
+	feasibleWithoutAll, err := isFeasible(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if !feasibleWithoutAll {
+		return nil, nil
+	}
+
+	conflicting := items
+	for i := len(items) - 1; i >= 0; i-- {
+		candidate := slices.Delete(slices.Clone(conflicting), i, i+1)
+
+		feasible, err := isFeasible(candidate)
+		if err != nil {
+			return nil, err
+		}
+
+		if !feasible {
+			conflicting = candidate
+		}
+	}
+
+	return conflicting, nil
+}
@@ -0,0 +1,32 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/utils"
+)
+
+func Test_findMinimalConflict_givenConflictingPair_shouldReturnPair(t *testing.T) {
+	isFeasible := func(kept []string) (bool, error) {
+		return !utils.ContainsAll(kept, []string{"b", "d"}), nil
+	}
+
+	got, err := findMinimalConflict([]string{"a", "b", "c", "d"}, isFeasible)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "d"}, got)
+}
+
+func Test_findMinimalConflict_givenInfeasibleWithoutItems_shouldReturnNil(t *testing.T) {
+	isFeasible := func([]string) (bool, error) {
+		return false, nil
+	}
+
+	got, err := findMinimalConflict([]string{"a", "b"}, isFeasible)
+
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
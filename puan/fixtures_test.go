@@ -0,0 +1,19 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Ruleset where exactly one of x and y is set, and z is independent
+func newXorRuleset(t *testing.T) Ruleset {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "z")
+	xor, _ := creator.SetXor("x", "y")
+	_ = creator.Assume(xor)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	return ruleset
+}
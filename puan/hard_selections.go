@@ -0,0 +1,81 @@
+package puan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+// Returned when hard selections cannot all be met. Wraps
+// puanerror.SelectionConflict as well as puanerror.SolverFailed.
+type SelectionConflictError struct {
+	conflictingSelections Selections
+}
+
+func (e *SelectionConflictError) Error() string {
+	descriptions := make([]string, len(e.conflictingSelections))
+	for i, selection := range e.conflictingSelections {
+		descriptions[i] = fmt.Sprintf("%s %v", selection.action, selection.IDs())
+	}
+
+	return fmt.Sprintf(
+		"%s: hard selections cannot be met together: %s",
+		puanerror.SelectionConflict,
+		strings.Join(descriptions, ", "),
+	)
+}
+
+func (e *SelectionConflictError) Unwrap() []error {
+	return []error{puanerror.SelectionConflict, puanerror.SolverFailed}
+}
+
+// Minimal set of hard selections that cannot be met together,
+// i.e. removing any of them resolves the conflict.
+func (e *SelectionConflictError) ConflictingSelections() Selections {
+	return e.conflictingSelections
+}
+
+// Replaces a solver failure caused by hard selections
+// with an explanation of the conflict.
+func (c *SolutionCreator) explainSolveError(err error, query SolutionQuery) error {
+	err = updateSolveError(err, query.ruleset, query.from)
+	if !errors.Is(err, puanerror.SolverFailed) {
+		return err
+	}
+
+	dependentSelections, _ :=
+		categorizeSelections(query.selections, query.ruleset.independentVariables)
+	hard := dependentSelections.prepareForQuery().hardSelections()
+	if len(hard) == 0 {
+		return err
+	}
+
+	conflicting, explainErr := c.findConflictingHardSelections(query, hard)
+	if explainErr != nil {
+		return explainErr
+	}
+
+	if len(conflicting) == 0 {
+		return err
+	}
+
+	return errors.Wrap(&SelectionConflictError{conflictingSelections: conflicting}, 0)
+}
+
+// Minimal set of the hard selections that make the query infeasible
+func (c *SolutionCreator) findConflictingHardSelections(
+	query SolutionQuery,
+	hard Selections,
+) (Selections, error) {
+	return findMinimalConflict(hard, func(kept Selections) (bool, error) {
+		ruleset, err := query.ruleset.modifyForQuery(kept, query.from, query.to)
+		if err != nil {
+			return false, err
+		}
+
+		return c.isFeasible(ruleset)
+	})
+}
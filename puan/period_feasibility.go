@@ -0,0 +1,135 @@
+package puan
+
+import (
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/utils"
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+type PeriodFeasibility struct {
+	periodVariable         TimeBoundVariable
+	feasible               bool
+	conflictingAssumptions TimeBoundVariables
+}
+
+func (f PeriodFeasibility) Period() Period {
+	return f.periodVariable.period
+}
+
+func (f PeriodFeasibility) Variable() string {
+	return f.periodVariable.variable
+}
+
+func (f PeriodFeasibility) IsFeasible() bool {
+	return f.feasible
+}
+
+// Time-bound assumptions that together make the period infeasible.
+// Empty if the period is feasible, if the period is infeasible
+// regardless of the time-bound assumptions, or if the ruleset was not
+// created with RulesetCreator.EnablePeriodFeasibilityAnalysis.
+func (f PeriodFeasibility) ConflictingAssumptions() TimeBoundVariables {
+	return f.conflictingAssumptions
+}
+
+// Analyses, for every partitioned period of the ruleset,
+// whether a configuration exists in that period.
+// For infeasible periods, a minimal set of conflicting
+// time-bound assumptions is reported.
+func (c *SolutionCreator) AnalyzePeriodFeasibility(
+	ruleset Ruleset,
+) ([]PeriodFeasibility, error) {
+	if ruleset.timeDisabled() {
+		return nil, errors.Errorf(
+			"%w: ruleset has no periods to analyse",
+			puanerror.InvalidOperation,
+		)
+	}
+
+	feasibilities := make([]PeriodFeasibility, len(ruleset.periodVariables))
+	for i, periodVariable := range ruleset.periodVariables {
+		feasibility, err := c.analyzePeriodFeasibility(ruleset, periodVariable)
+		if err != nil {
+			return nil, err
+		}
+
+		feasibilities[i] = feasibility
+	}
+
+	return feasibilities, nil
+}
+
+func (c *SolutionCreator) analyzePeriodFeasibility(
+	ruleset Ruleset,
+	periodVariable TimeBoundVariable,
+) (PeriodFeasibility, error) {
+	feasible, err := c.isPeriodFeasible(ruleset, periodVariable, nil)
+	if err != nil {
+		return PeriodFeasibility{}, err
+	}
+
+	if feasible {
+		return PeriodFeasibility{
+			periodVariable: periodVariable,
+			feasible:       true,
+		}, nil
+	}
+
+	conflicting, err := c.findConflictingAssumptions(ruleset, periodVariable)
+	if err != nil {
+		return PeriodFeasibility{}, err
+	}
+
+	return PeriodFeasibility{
+		periodVariable:         periodVariable,
+		feasible:               false,
+		conflictingAssumptions: conflicting,
+	}, nil
+}
+
+// Minimal set of the time-bound assumptions in force in the period
+// that make the period infeasible
+func (c *SolutionCreator) findConflictingAssumptions(
+	ruleset Ruleset,
+	periodVariable TimeBoundVariable,
+) (TimeBoundVariables, error) {
+	inForce := ruleset.timeBoundAssumedVariables.
+		overlapping(periodVariable.period).
+		relaxable()
+
+	return findMinimalConflict(inForce, func(kept TimeBoundVariables) (bool, error) {
+		relaxed := utils.Filter(inForce, func(variable TimeBoundVariable) bool {
+			return !kept.contains(variable)
+		})
+
+		return c.isPeriodFeasible(ruleset, periodVariable, relaxed)
+	})
+}
+
+func (c *SolutionCreator) isPeriodFeasible(
+	ruleset Ruleset,
+	periodVariable TimeBoundVariable,
+	relaxed TimeBoundVariables,
+) (bool, error) {
+	periodRuleset := ruleset.copy()
+
+	err := periodRuleset.relaxTimeBoundAssumptions(relaxed)
+	if err != nil {
+		return false, err
+	}
+
+	err = periodRuleset.assume(periodVariable.variable)
+	if err != nil {
+		return false, err
+	}
+
+	return c.isFeasible(periodRuleset)
+}
+
+// Variables with a constraint of their own, which can be relaxed
+func (variables TimeBoundVariables) relaxable() TimeBoundVariables {
+	return utils.Filter(variables, func(variable TimeBoundVariable) bool {
+		return variable.constraintID != ""
+	})
+}
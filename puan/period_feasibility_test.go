@@ -0,0 +1,179 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+func Test_SolutionCreator_AnalyzePeriodFeasibility_givenConflictingAssumptions_shouldReportConflict(
+	t *testing.T,
+) {
+	minute0 := newTestTime("2024-01-01T00:00:00Z")
+	minute15 := newTestTime("2024-01-01T00:15:00Z")
+	minute30 := newTestTime("2024-01-01T00:30:00Z")
+	minute60 := newTestTime("2024-01-01T01:00:00Z")
+
+	creator := NewRulesetCreator()
+	creator.EnablePeriodFeasibilityAnalysis()
+	_ = creator.AddPrimitives("x", "y")
+	notX, _ := creator.SetNot("x")
+	_ = creator.EnableTime(minute0, minute60)
+	_ = creator.AssumeInPeriod("x", minute0, minute30)
+	_ = creator.AssumeInPeriod(notX, minute15, minute60)
+	_ = creator.AssumeInPeriod("y", minute15, minute30)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+	feasibilities, err := solutionCreator.AnalyzePeriodFeasibility(ruleset)
+	require.NoError(t, err)
+	require.Len(t, feasibilities, 3)
+
+	assert.True(t, feasibilities[0].IsFeasible())
+	assert.False(t, feasibilities[1].IsFeasible())
+	assert.True(t, feasibilities[2].IsFeasible())
+
+	assert.Equal(t, minute15, feasibilities[1].Period().From())
+	assert.Equal(t, minute30, feasibilities[1].Period().To())
+	assert.ElementsMatch(
+		t,
+		[]string{"x", notX},
+		feasibilities[1].ConflictingAssumptions().ids(),
+	)
+}
+
+func Test_SolutionCreator_AnalyzePeriodFeasibility_givenTimeDisabled_shouldReturnError(
+	t *testing.T,
+) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x")
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+	_, err := solutionCreator.AnalyzePeriodFeasibility(ruleset)
+
+	assert.ErrorIs(t, err, puanerror.InvalidOperation)
+}
+
+func Test_SolutionCreator_AnalyzePeriodFeasibility_givenAnalysisNotEnabled_shouldReportNoConflict(
+	t *testing.T,
+) {
+	minute0 := newTestTime("2024-01-01T00:00:00Z")
+	minute15 := newTestTime("2024-01-01T00:15:00Z")
+	minute30 := newTestTime("2024-01-01T00:30:00Z")
+	minute60 := newTestTime("2024-01-01T01:00:00Z")
+
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x")
+	notX, _ := creator.SetNot("x")
+	_ = creator.EnableTime(minute0, minute60)
+	_ = creator.AssumeInPeriod("x", minute0, minute30)
+	_ = creator.AssumeInPeriod(notX, minute15, minute60)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+	feasibilities, err := solutionCreator.AnalyzePeriodFeasibility(ruleset)
+	require.NoError(t, err)
+	require.Len(t, feasibilities, 3)
+
+	assert.False(t, feasibilities[1].IsFeasible())
+	assert.Empty(t, feasibilities[1].ConflictingAssumptions())
+	assert.Empty(t, ruleset.TimeBoundAssumedVariables()[0].ConstraintID())
+}
+
+func Test_Ruleset_relaxTimeBoundAssumptions_givenSameVariableTwice_shouldRemoveOneRow(
+	t *testing.T,
+) {
+	creator := NewRulesetCreator()
+	creator.EnablePeriodFeasibilityAnalysis()
+	_ = creator.AddPrimitives("x")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-01T01:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		"x",
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-01T00:30:00Z"),
+	)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	assumed := ruleset.TimeBoundAssumedVariables()[0]
+	rows := len(ruleset.Polyhedron().B())
+
+	err = ruleset.relaxTimeBoundAssumptions(TimeBoundVariables{assumed, assumed})
+
+	require.NoError(t, err)
+	assert.Len(t, ruleset.Polyhedron().B(), rows-1)
+}
+
+func Test_Ruleset_relaxTimeBoundAssumptions_givenAnalysisNotEnabled_shouldReturnError(
+	t *testing.T,
+) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-01T01:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		"x",
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-01T00:30:00Z"),
+	)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	err = ruleset.relaxTimeBoundAssumptions(ruleset.TimeBoundAssumedVariables())
+
+	assert.ErrorIs(t, err, puanerror.InvalidOperation)
+}
+
+func Test_SolutionCreator_AnalyzePeriodFeasibility_givenHydratedRuleset_shouldReportConflict(
+	t *testing.T,
+) {
+	minute0 := newTestTime("2024-01-01T00:00:00Z")
+	minute15 := newTestTime("2024-01-01T00:15:00Z")
+	minute30 := newTestTime("2024-01-01T00:30:00Z")
+	minute60 := newTestTime("2024-01-01T01:00:00Z")
+
+	creator := NewRulesetCreator()
+	creator.EnablePeriodFeasibilityAnalysis()
+	_ = creator.AddPrimitives("x")
+	notX, _ := creator.SetNot("x")
+	_ = creator.EnableTime(minute0, minute60)
+	_ = creator.AssumeInPeriod("x", minute0, minute30)
+	_ = creator.AssumeInPeriod(notX, minute15, minute60)
+	original, err := creator.Create()
+	require.NoError(t, err)
+
+	ruleset, err := HydrateSparseRuleSet(
+		original.Polyhedron().SparseMatrix(),
+		original.Polyhedron().B(),
+		original.DependentVariables(),
+		original.IndependentVariables(),
+		original.SelectableVariables(),
+		original.PreferredVariables(),
+		original.PeriodVariables(),
+		WithTimeBoundAssumedVariables(original.TimeBoundAssumedVariables()),
+	)
+	require.NoError(t, err)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+	feasibilities, err := solutionCreator.AnalyzePeriodFeasibility(ruleset)
+	require.NoError(t, err)
+	require.Len(t, feasibilities, 3)
+
+	assert.False(t, feasibilities[1].IsFeasible())
+	assert.ElementsMatch(
+		t,
+		[]string{"x", notX},
+		feasibilities[1].ConflictingAssumptions().ids(),
+	)
+}
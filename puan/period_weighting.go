@@ -0,0 +1,79 @@
+package puan
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/weights"
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+const (
+	EARLIEST PeriodDirection = "EARLIEST"
+	LATEST   PeriodDirection = "LATEST"
+	CLOSEST  PeriodDirection = "CLOSEST"
+)
+
+// Which period the solver should prefer, when several are possible
+type PeriodDirection string
+
+// How periods are weighted against each other and against preferreds
+type periodWeighting struct {
+	direction PeriodDirection
+	// Required for CLOSEST
+	target *time.Time
+	// Preferreds outweigh periods instead of the other way around
+	preferredsFirst bool
+}
+
+func (w periodWeighting) validate() error {
+	switch w.direction {
+	case "", EARLIEST, LATEST:
+		return nil
+	case CLOSEST:
+		if w.target == nil {
+			return errors.Errorf(
+				"%w: target time is required for period direction %s",
+				puanerror.InvalidArgument,
+				CLOSEST,
+			)
+		}
+		return nil
+	default:
+		return errors.Errorf(
+			"%w: unknown period direction '%s'",
+			puanerror.InvalidArgument,
+			w.direction,
+		)
+	}
+}
+
+// Period IDs ordered by priority, the most wanted first
+func (w periodWeighting) orderPeriodIDs(periodVariables TimeBoundVariables) []string {
+	ordered := slices.Clone(periodVariables)
+
+	switch w.direction {
+	case LATEST:
+		slices.Reverse(ordered)
+	case CLOSEST:
+		slices.SortStableFunc(ordered, func(a, b TimeBoundVariable) int {
+			return cmp.Compare(
+				a.period.distanceTo(*w.target),
+				b.period.distanceTo(*w.target),
+			)
+		})
+	}
+
+	return ordered.ids()
+}
+
+func (w periodWeighting) periodPriority() weights.PeriodPriority {
+	if w.preferredsFirst {
+		return weights.PREFERREDS_BEFORE_PERIODS
+	}
+
+	return weights.PERIODS_BEFORE_PREFERREDS
+}
@@ -0,0 +1,101 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+func Test_SolutionCreator_Create_givenPeriodWeighting_shouldChoosePeriod(t *testing.T) {
+	target := newTestTime("2024-01-12T00:00:00Z")
+	theories := []struct {
+		name    string
+		builder *SolutionQueryBuilder
+		want    Period
+	}{
+		{
+			name:    "default is earliest",
+			builder: NewSolutionQueryBuilder(),
+			want:    newTestPeriod("2024-01-01T00:00:00Z", "2024-01-10T00:00:00Z"),
+		},
+		{
+			name:    "latest",
+			builder: NewSolutionQueryBuilder().WithPeriodDirection(LATEST),
+			want:    newTestPeriod("2024-01-25T00:00:00Z", "2024-01-31T00:00:00Z"),
+		},
+		{
+			name: "closest to target",
+			builder: NewSolutionQueryBuilder().
+				WithPeriodDirection(CLOSEST).
+				WithTargetTime(&target),
+			want: newTestPeriod("2024-01-10T00:00:00Z", "2024-01-20T00:00:00Z"),
+		},
+		{
+			name:    "preferreds before earliest",
+			builder: NewSolutionQueryBuilder().WithPreferredsBeforePeriods(true),
+			want:    newTestPeriod("2024-01-10T00:00:00Z", "2024-01-20T00:00:00Z"),
+		},
+	}
+
+	// x is preferred, but only available from the 10th
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x")
+	notX, _ := creator.SetNot("x")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-10T00:00:00Z"),
+	)
+	_ = creator.ForbidPeriod(
+		newTestTime("2024-01-20T00:00:00Z"),
+		newTestTime("2024-01-25T00:00:00Z"),
+	)
+	_ = creator.Prefer("x")
+	ruleset, _ := creator.Create()
+
+	for _, tt := range theories {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, err := NewSolutionCreator(bruteForceSolverClient{}).Create(
+				tt.builder.WithRuleset(ruleset).Build(),
+			)
+			require.NoError(t, err)
+
+			period, err := ruleset.FindPeriodInSolution(envelope.Solution())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, period)
+		})
+	}
+}
+
+func Test_SolutionQuery_validate_givenClosestWithoutTarget_shouldReturnError(t *testing.T) {
+	ruleset := newXorRuleset(t)
+
+	query := NewSolutionQueryBuilder().
+		WithRuleset(ruleset).
+		WithPeriodDirection(CLOSEST).
+		Build()
+
+	err := query.validate()
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_SolutionQuery_validate_givenUnknownPeriodDirection_shouldReturnError(t *testing.T) {
+	ruleset := newXorRuleset(t)
+
+	query := NewSolutionQueryBuilder().
+		WithRuleset(ruleset).
+		WithPeriodDirection("SOONISH").
+		Build()
+
+	err := query.validate()
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
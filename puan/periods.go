@@ -1,6 +1,8 @@
 package puan
 
 import (
+	"cmp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -15,48 +17,166 @@ type (
 	Period struct {
 		from time.Time
 		to   time.Time
+		// Open ends are earlier or later than any time,
+		// and have the zero time as their time
+		openStart bool
+		openEnd   bool
 	}
 	ManyPeriods []Period
 )
 
+// Start or end of a period, where open ends
+// are earlier or later than any time
+type periodEdge struct {
+	time time.Time
+	// -1 for an open start, 1 for an open end and 0 otherwise
+	open int
+}
+
+func newTimeEdge(t time.Time) periodEdge {
+	return periodEdge{time: t}
+}
+
+// Edge at the time, or an open start if there is no time
+func newStartEdge(from *time.Time) periodEdge {
+	if from == nil {
+		return periodEdge{open: -1}
+	}
+
+	return newTimeEdge(*from)
+}
+
+// Edge at the time, or an open end if there is no time
+func newEndEdge(to *time.Time) periodEdge {
+	if to == nil {
+		return periodEdge{open: 1}
+	}
+
+	return newTimeEdge(*to)
+}
+
+func (e periodEdge) compare(other periodEdge) int {
+	if e.open != 0 || other.open != 0 {
+		return cmp.Compare(e.open, other.open)
+	}
+
+	return e.time.Compare(other.time)
+}
+
+func (e periodEdge) String() string {
+	if e.open != 0 {
+		return "open"
+	}
+
+	return e.time.String()
+}
+
 func NewPeriod(from, to time.Time) (Period, error) {
-	if !to.After(from) {
+	return newPeriod(newTimeEdge(from), newTimeEdge(to))
+}
+
+// Period with an open start if from is nil and an open end if to is nil,
+// e.g. NewOpenPeriod(&from, nil) for a period from a time onwards
+func NewOpenPeriod(from, to *time.Time) (Period, error) {
+	return newPeriod(newStartEdge(from), newEndEdge(to))
+}
+
+func newPeriod(start, end periodEdge) (Period, error) {
+	if end.compare(start) <= 0 {
 		return Period{},
 			errors.Errorf(
 				"%w: from time %v must be before to time %v",
 				puanerror.InvalidArgument,
-				from,
-				to,
+				start,
+				end,
 			)
 	}
 
+	start.time = start.time.Truncate(time.Second)
+	end.time = end.time.Truncate(time.Second)
+
+	return newPeriodBetween(start, end), nil
+}
+
+func newPeriodBetween(start, end periodEdge) Period {
 	return Period{
-		from: from.Truncate(time.Second),
-		to:   to.Truncate(time.Second),
-	}, nil
+		from:      start.time,
+		to:        end.time,
+		openStart: start.open < 0,
+		openEnd:   end.open > 0,
+	}
 }
 
+// Start of the period, the zero time if the period has an open start
 func (p Period) From() time.Time {
 	return p.from
 }
 
+// End of the period, the zero time if the period has an open end
 func (p Period) To() time.Time {
 	return p.to
 }
 
+func (p Period) IsOpenStart() bool {
+	return p.openStart
+}
+
+func (p Period) IsOpenEnd() bool {
+	return p.openEnd
+}
+
+func (p Period) isBounded() bool {
+	return !p.openStart && !p.openEnd
+}
+
+func (p Period) start() periodEdge {
+	if p.openStart {
+		return periodEdge{time: p.from, open: -1}
+	}
+
+	return periodEdge{time: p.from}
+}
+
+func (p Period) end() periodEdge {
+	if p.openEnd {
+		return periodEdge{time: p.to, open: 1}
+	}
+
+	return periodEdge{time: p.to}
+}
+
 // Checks overlap, excluding edges
 func (p Period) overlaps(other Period) bool {
-	return p.from.Before(other.to) && p.to.After(other.from)
+	return p.start().compare(other.end()) < 0 && p.end().compare(other.start()) > 0
 }
 
 // Checks if period contains another, including edges
 func (p Period) contains(other Period) bool {
-	return !other.from.Before(p.from) && !other.to.After(p.to)
+	return other.start().compare(p.start()) >= 0 && other.end().compare(p.end()) <= 0
+}
+
+// Time from the period to the timestamp, zero if the period contains it
+func (p Period) distanceTo(timestamp time.Time) time.Duration {
+	edge := newTimeEdge(timestamp)
+	if edge.compare(p.start()) < 0 {
+		return p.from.Sub(timestamp)
+	}
+
+	if edge.compare(p.end()) >= 0 {
+		return timestamp.Sub(p.to)
+	}
+
+	return 0
+}
+
+// Whether the period starts where the other ends
+func (p Period) follows(other Period) bool {
+	return p.start().compare(other.end()) == 0
 }
 
 func (p Period) isEqual(other Period) bool {
-	equalFrom := p.from.Equal(other.from)
-	equalTo := p.to.Equal(other.to)
+	equalFrom := p.start().compare(other.start()) == 0
+	equalTo := p.end().compare(other.end()) == 0
 	return equalFrom && equalTo
 }
 
@@ -74,6 +194,10 @@ type TimeBoundVariables []TimeBoundVariable
 type TimeBoundVariable struct {
 	variable string
 	period   Period
+	// Constraint assuming the variable in its period on a row of its own,
+	// only set on the time-bound assumed variables of rulesets created
+	// with RulesetCreator.EnablePeriodFeasibilityAnalysis
+	constraintID string
 }
 
 func NewTimeBoundVariable(variable string, period Period) TimeBoundVariable {
@@ -83,6 +207,20 @@ func NewTimeBoundVariable(variable string, period Period) TimeBoundVariable {
 	}
 }
 
+// Time-bound assumed variable with the ID of its constraint,
+// e.g. for hydrating a ruleset, see WithTimeBoundAssumedVariables
+func NewTimeBoundAssumedVariable(
+	variable string,
+	period Period,
+	constraintID string,
+) TimeBoundVariable {
+	return TimeBoundVariable{
+		variable:     variable,
+		period:       period,
+		constraintID: constraintID,
+	}
+}
+
 func (variable TimeBoundVariable) Period() Period {
 	return variable.period
 }
@@ -91,6 +229,10 @@ func (variable TimeBoundVariable) Variable() string {
 	return variable.variable
 }
 
+func (variable TimeBoundVariable) ConstraintID() string {
+	return variable.constraintID
+}
+
 func (variable TimeBoundVariable) containsAny(periods []Period) bool {
 	for _, period := range periods {
 		if variable.period.contains(period) {
@@ -117,19 +259,32 @@ func (p TimeBoundVariables) ids() []string {
 }
 
 func (p TimeBoundVariables) earlierThan(timestamp time.Time) TimeBoundVariables {
+	edge := newTimeEdge(timestamp)
 	return utils.Filter(p, func(periodVariable TimeBoundVariable) bool {
-		isEarlier := !periodVariable.period.to.After(timestamp)
+		isEarlier := periodVariable.period.end().compare(edge) <= 0
 		return isEarlier
 	})
 }
 
 func (p TimeBoundVariables) laterThan(timestamp time.Time) TimeBoundVariables {
+	edge := newTimeEdge(timestamp)
 	return utils.Filter(p, func(periodVariable TimeBoundVariable) bool {
-		isLater := !periodVariable.period.from.Before(timestamp)
+		isLater := periodVariable.period.start().compare(edge) >= 0
 		return isLater
 	})
 }
 
+// Variables with periods that are neither passed at from nor future at to
+func (p TimeBoundVariables) between(from, to *time.Time) TimeBoundVariables {
+	return utils.Filter(p, func(periodVariable TimeBoundVariable) bool {
+		isPassed := from != nil &&
+			periodVariable.period.end().compare(newTimeEdge(*from)) <= 0
+		isFuture := to != nil &&
+			periodVariable.period.start().compare(newTimeEdge(*to)) >= 0
+		return !isPassed && !isFuture
+	})
+}
+
 func (variables TimeBoundVariables) containing(periods []Period) TimeBoundVariables {
 	return utils.Filter(
 		variables,
@@ -139,6 +294,28 @@ func (variables TimeBoundVariables) containing(periods []Period) TimeBoundVariab
 	)
 }
 
+func (variables TimeBoundVariables) overlapping(period Period) TimeBoundVariables {
+	return utils.Filter(
+		variables,
+		func(variable TimeBoundVariable) bool {
+			return variable.period.overlaps(period)
+		},
+	)
+}
+
+func (variables TimeBoundVariables) contains(other TimeBoundVariable) bool {
+	for _, variable := range variables {
+		if variable.isEqual(other) {
+			return true
+		}
+	}
+	return false
+}
+
+func (variable TimeBoundVariable) isEqual(other TimeBoundVariable) bool {
+	return variable.variable == other.variable && variable.period.isEqual(other.period)
+}
+
 // find all periods without gaps or overlaps, sorted by start time
 // Input:
 // |----------------------|
@@ -158,14 +335,19 @@ func calculatePartitionedPeriods(
 	sortedEdges := getSortedPeriodEdges(periods)
 	completePeriods := toPeriods(sortedEdges)
 
-	return completePeriods
+	return withOpenEnds(completePeriods, sortedEdges, periods)
 }
 
+// Closed edges of the periods, open ends have no time
 func getSortedPeriodEdges(periods []Period) []time.Time {
 	edges := make(map[time.Time]bool)
 	for _, period := range periods {
-		edges[period.from] = true
-		edges[period.to] = true
+		if !period.openStart {
+			edges[period.from] = true
+		}
+		if !period.openEnd {
+			edges[period.to] = true
+		}
 	}
 
 	var sortedEdges []time.Time
@@ -194,6 +376,32 @@ func toPeriods(edges []time.Time) []Period {
 	return periods
 }
 
+// Adds the periods before the first and after the last
+// closed edge if any of the periods is open there
+func withOpenEnds(
+	partitioned []Period,
+	sortedEdges []time.Time,
+	periods []Period,
+) []Period {
+	openStart := slices.ContainsFunc(periods, Period.IsOpenStart)
+	openEnd := slices.ContainsFunc(periods, Period.IsOpenEnd)
+	if len(sortedEdges) == 0 {
+		return []Period{{openStart: openStart, openEnd: openEnd}}
+	}
+
+	if openStart {
+		first := Period{openStart: true, to: sortedEdges[0]}
+		partitioned = append([]Period{first}, partitioned...)
+	}
+
+	if openEnd {
+		last := Period{from: sortedEdges[len(sortedEdges)-1], openEnd: true}
+		partitioned = append(partitioned, last)
+	}
+
+	return partitioned
+}
+
 func filterOutForbiddenPeriods(
 	periods []Period,
 	forbiddenPeriods ManyPeriods,
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ourstudio-se/puan-sdk-go/internal/fake"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_NewPeriod(t *testing.T) {
@@ -516,6 +517,24 @@ func Test_calculatePartitionedPeriods(t *testing.T) {
 	}
 }
 
+func Test_calculatePartitionedPeriods_givenOpenEnds_shouldKeepThemOnOuterPeriods(
+	t *testing.T,
+) {
+	from := newTestTime("2024-01-01T00:00:00Z")
+	to := newTestTime("2024-01-31T00:00:00Z")
+	until, _ := NewOpenPeriod(nil, &to)
+	onwards, _ := NewOpenPeriod(&from, nil)
+
+	actual := calculatePartitionedPeriods([]Period{until, onwards})
+
+	expected := []Period{
+		{openStart: true, to: from},
+		{from: from, to: to},
+		{from: to, openEnd: true},
+	}
+	assert.Equal(t, expected, actual)
+}
+
 func Test_getSortedPeriodEdges(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1224,3 +1243,79 @@ func newTestTime(value string) time.Time {
 
 	return t
 }
+
+func Test_NewOpenPeriod_givenNoTo_shouldReturnOpenEndedPeriod(t *testing.T) {
+	from := newTestTime("2024-01-01T00:00:00Z")
+
+	actual, err := NewOpenPeriod(&from, nil)
+
+	assert.NoError(t, err)
+	assert.False(t, actual.IsOpenStart())
+	assert.True(t, actual.IsOpenEnd())
+	assert.True(t, actual.contains(newTestPeriod("2024-01-01T00:00:00Z", "9999-12-31T00:00:00Z")))
+}
+
+func Test_NewOpenPeriod_givenNoFrom_shouldReturnOpenStartedPeriod(t *testing.T) {
+	to := newTestTime("2024-01-01T00:00:00Z")
+
+	actual, err := NewOpenPeriod(nil, &to)
+
+	assert.NoError(t, err)
+	assert.True(t, actual.IsOpenStart())
+	assert.False(t, actual.IsOpenEnd())
+}
+
+func Test_NewPeriod_givenFarFutureAndPastTimes_shouldReturnClosedPeriod(t *testing.T) {
+	actual, err := NewPeriod(
+		time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(9999, time.December, 31, 23, 59, 59, 0, time.UTC),
+	)
+
+	assert.NoError(t, err)
+	assert.False(t, actual.IsOpenStart())
+	assert.False(t, actual.IsOpenEnd())
+}
+
+func Test_NewOpenPeriod_givenNoTimes_shouldReturnUnboundedPeriod(t *testing.T) {
+	actual, err := NewOpenPeriod(nil, nil)
+
+	assert.NoError(t, err)
+	assert.True(t, actual.IsOpenStart())
+	assert.True(t, actual.IsOpenEnd())
+	assert.True(t, actual.From().IsZero())
+	assert.True(t, actual.To().IsZero())
+}
+
+func Test_Period_givenOpenEnds_shouldCompareBeyondAnyTime(t *testing.T) {
+	from := newTestTime("2024-01-01T00:00:00Z")
+	onwards, err := NewOpenPeriod(&from, nil)
+	require.NoError(t, err)
+	farFuture := newTestPeriod("2024-01-01T00:00:00Z", "9999-12-31T23:59:59Z")
+
+	assert.True(t, onwards.contains(farFuture))
+	assert.False(t, farFuture.contains(onwards))
+	assert.False(t, onwards.isEqual(farFuture))
+}
+
+func Test_TimeBoundVariables_givenOpenEndedPeriods_shouldFilterByTimestamp(t *testing.T) {
+	timestamp := newTestTime("2024-01-01T00:00:00Z")
+	until, _ := NewOpenPeriod(nil, &timestamp)
+	onwards, _ := NewOpenPeriod(&timestamp, nil)
+	variables := TimeBoundVariables{
+		NewTimeBoundVariable("until", until),
+		NewTimeBoundVariable("onwards", onwards),
+	}
+
+	assert.Equal(t, []string{"until"}, variables.earlierThan(timestamp).ids())
+	assert.Equal(t, []string{"onwards"}, variables.laterThan(timestamp).ids())
+	assert.Empty(t, variables.earlierThan(newTestTime("2023-01-01T00:00:00Z")).ids())
+	assert.Empty(t, variables.laterThan(newTestTime("2025-01-01T00:00:00Z")).ids())
+}
+
+func Test_Period_distanceTo(t *testing.T) {
+	period := newTestPeriod("2024-01-10T00:00:00Z", "2024-01-20T00:00:00Z")
+
+	assert.Equal(t, 48*time.Hour, period.distanceTo(newTestTime("2024-01-08T00:00:00Z")))
+	assert.Equal(t, time.Duration(0), period.distanceTo(newTestTime("2024-01-10T00:00:00Z")))
+	assert.Equal(t, 24*time.Hour, period.distanceTo(newTestTime("2024-01-21T00:00:00Z")))
+}
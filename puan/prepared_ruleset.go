@@ -0,0 +1,125 @@
+package puan
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/cache"
+	"github.com/ourstudio-se/puan-sdk-go/internal/utils"
+)
+
+const DefaultPreparedRulesetCapacity = 128
+
+// Ruleset with a cache of rulesets modified for queries.
+// Queries with the same composite selections and the same
+// allowed periods share the modified ruleset, which avoids copying
+// and converting the polyhedron for every query.
+// Safe for concurrent use.
+type PreparedRuleset struct {
+	ruleset  Ruleset
+	capacity int
+	cache    *cache.LRU[string, Ruleset]
+	// Prepared rulesets of the components touched by queries
+	touched *cache.LRU[string, *PreparedRuleset]
+}
+
+// Capacity is the maximum number of modified rulesets kept in the cache
+func NewPreparedRuleset(ruleset Ruleset, capacity int) *PreparedRuleset {
+	return &PreparedRuleset{
+		ruleset:  ruleset,
+		capacity: capacity,
+		cache:    cache.NewLRU[string, Ruleset](capacity),
+		touched:  cache.NewLRU[string, *PreparedRuleset](capacity),
+	}
+}
+
+func (p *PreparedRuleset) Ruleset() Ruleset {
+	return p.ruleset
+}
+
+func (p *PreparedRuleset) modifyForQuery(
+	selections Selections,
+	from *time.Time,
+	to *time.Time,
+) (Ruleset, error) {
+	key, err := p.newQueryKey(selections, from, to)
+	if err != nil {
+		return Ruleset{}, err
+	}
+
+	if ruleset, ok := p.cache.Get(key); ok {
+		return ruleset, nil
+	}
+
+	ruleset, err := p.ruleset.modifyForQuery(selections, from, to)
+	if err != nil {
+		return Ruleset{}, err
+	}
+
+	ruleset.polyhedron.PrecomputeSparseMatrix()
+	p.cache.Add(key, ruleset)
+
+	return ruleset, nil
+}
+
+// Prepared ruleset of the touched components, shared by queries
+// touching the same components, see Ruleset.newTouchedRuleset
+func (p *PreparedRuleset) forTouchedComponents(touched []int) *PreparedRuleset {
+	indices := make([]string, len(touched))
+	for i, index := range touched {
+		indices[i] = strconv.Itoa(index)
+	}
+	key := strings.Join(indices, ",")
+
+	if prepared, ok := p.touched.Get(key); ok {
+		return prepared
+	}
+
+	prepared := NewPreparedRuleset(p.ruleset.newTouchedRuleset(touched), p.capacity)
+	p.touched.Add(key, prepared)
+
+	return prepared
+}
+
+// The modified ruleset only depends on the composite and hard selections
+// and on which periods are forbidden by from and to
+func (p *PreparedRuleset) newQueryKey(
+	selections Selections,
+	from *time.Time,
+	to *time.Time,
+) (string, error) {
+	var compositeIDs []string
+	for _, selection := range selections {
+		if !selection.IsComposite() {
+			continue
+		}
+
+		constraint, err := newIdentifyingConstraint(selection)
+		if err != nil {
+			return "", err
+		}
+
+		compositeIDs = append(compositeIDs, constraint.ID())
+	}
+
+	var hardIDs []string
+	for _, selection := range selections.prepareForQuery().hardSelections() {
+		hardIDs = append(hardIDs, selection.Hash())
+	}
+
+	forbiddenPeriodIDs := p.ruleset.findForbiddenPeriodIDs(from, to)
+
+	composite := utils.Dedupe(compositeIDs)
+	sort.Strings(composite)
+	forbidden := utils.Dedupe(forbiddenPeriodIDs)
+	sort.Strings(forbidden)
+
+	hard := utils.Dedupe(hardIDs)
+	sort.Strings(hard)
+
+	return strings.Join(composite, ",") + "|" +
+		strings.Join(forbidden, ",") + "|" +
+		strings.Join(hard, ","), nil
+}
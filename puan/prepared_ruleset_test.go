@@ -0,0 +1,125 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PreparedRuleset_modifyForQuery_givenSameComposite_shouldReuseRuleset(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	prepared := NewPreparedRuleset(ruleset, DefaultPreparedRulesetCapacity)
+
+	first := Selections{NewSelectionBuilder("x").Build()}
+	second := Selections{NewSelectionBuilder("y").Build()}
+
+	firstRuleset, err := prepared.modifyForQuery(first, nil, nil)
+	require.NoError(t, err)
+	secondRuleset, err := prepared.modifyForQuery(second, nil, nil)
+	require.NoError(t, err)
+
+	assert.Same(t, firstRuleset.polyhedron, secondRuleset.polyhedron)
+}
+
+func Test_PreparedRuleset_modifyForQuery_givenDifferentComposite_shouldNotReuseRuleset(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	prepared := NewPreparedRuleset(ruleset, DefaultPreparedRulesetCapacity)
+
+	first := Selections{NewSelectionBuilder("x").Build()}
+	second := Selections{NewSelectionBuilder("x").WithSubSelectionID("y").Build()}
+
+	firstRuleset, err := prepared.modifyForQuery(first, nil, nil)
+	require.NoError(t, err)
+	secondRuleset, err := prepared.modifyForQuery(second, nil, nil)
+	require.NoError(t, err)
+
+	assert.NotSame(t, firstRuleset.polyhedron, secondRuleset.polyhedron)
+	assert.Len(t, secondRuleset.dependentVariables, len(firstRuleset.dependentVariables)+1)
+}
+
+func Test_PreparedRuleset_newQueryKey_givenFromInSamePeriod_shouldReturnSameKey(
+	t *testing.T,
+) {
+	minute0 := newTestTime("2024-01-01T00:00:00Z")
+	minute10 := newTestTime("2024-01-01T00:10:00Z")
+	minute20 := newTestTime("2024-01-01T00:20:00Z")
+	minute30 := newTestTime("2024-01-01T00:30:00Z")
+	minute60 := newTestTime("2024-01-01T01:00:00Z")
+
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x")
+	_ = creator.EnableTime(minute0, minute60)
+	_ = creator.AssumeInPeriod("x", minute0, minute30)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	prepared := NewPreparedRuleset(ruleset, DefaultPreparedRulesetCapacity)
+
+	first, err := prepared.newQueryKey(nil, &minute10, nil)
+	require.NoError(t, err)
+	second, err := prepared.newQueryKey(nil, &minute20, nil)
+	require.NoError(t, err)
+	third, err := prepared.newQueryKey(nil, &minute30, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, third)
+}
+
+func Test_SolutionCreator_Create_givenPreparedRuleset_shouldReturnSameSolution(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	prepared := NewPreparedRuleset(ruleset, DefaultPreparedRulesetCapacity)
+	selections := Selections{
+		NewSelectionBuilder("x").WithSubSelectionID("y").Build(),
+		NewSelectionBuilder("y").Build(),
+	}
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	want, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(selections).
+			Build(),
+	)
+	require.NoError(t, err)
+
+	for range 2 {
+		got, err := solutionCreator.Create(
+			NewSolutionQueryBuilder().
+				WithPreparedRuleset(prepared).
+				WithSelections(selections).
+				Build(),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, want.Solution(), got.Solution())
+	}
+}
+
+func Test_PreparedRuleset_newQueryKey_givenRequiredSelection_shouldDifferFromAdded(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	prepared := NewPreparedRuleset(ruleset, DefaultPreparedRulesetCapacity)
+
+	added, err := prepared.newQueryKey(Selections{NewSelectionBuilder("x").Build()}, nil, nil)
+	require.NoError(t, err)
+	required, err := prepared.newQueryKey(
+		Selections{NewSelectionBuilder("x").WithAction(REQUIRE).Build()},
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, added, required)
+}
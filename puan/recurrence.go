@@ -0,0 +1,291 @@
+package puan
+
+import (
+	"slices"
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+const (
+	DAILY   Frequency = "DAILY"
+	WEEKLY  Frequency = "WEEKLY"
+	MONTHLY Frequency = "MONTHLY"
+	YEARLY  Frequency = "YEARLY"
+)
+
+// Upper bound of the number of periods a recurrence may expand into,
+// protecting against long horizons with short occurrences.
+const DefaultMaxRecurrencePeriods = 1000
+
+// Upper bound of the number of periods all recurrences of a ruleset may
+// expand into together, see RulesetCreator.LimitRecurrencePeriods.
+const DefaultMaxTotalRecurrencePeriods = 5000
+
+type Frequency string
+
+// Time of day in the location of a recurrence, 24:00 being the end of the day
+type TimeOfDay struct {
+	hour   int
+	minute int
+}
+
+func NewTimeOfDay(hour, minute int) TimeOfDay {
+	return TimeOfDay{
+		hour:   hour,
+		minute: minute,
+	}
+}
+
+func (t TimeOfDay) minutes() int {
+	return t.hour*60 + t.minute
+}
+
+func (t TimeOfDay) isValid() bool {
+	return t.hour >= 0 && t.minute >= 0 && t.minute < 60 && t.minutes() <= 24*60
+}
+
+// Calendar rule, similar to an iCalendar RRULE, such as
+// "weekends", "every Q4" or "Mon-Fri 08-17 Europe/Stockholm".
+// Expanded into absolute periods within the enabled ruleset period.
+type Recurrence struct {
+	frequency  Frequency
+	interval   int
+	weekdays   []time.Weekday
+	months     []time.Month
+	start      TimeOfDay
+	end        TimeOfDay
+	location   *time.Location
+	maxPeriods int
+}
+
+type RecurrenceBuilder struct {
+	recurrence Recurrence
+}
+
+func NewRecurrenceBuilder(frequency Frequency) *RecurrenceBuilder {
+	return &RecurrenceBuilder{
+		recurrence: Recurrence{
+			frequency:  frequency,
+			interval:   1,
+			start:      NewTimeOfDay(0, 0),
+			end:        NewTimeOfDay(24, 0),
+			location:   time.UTC,
+			maxPeriods: DefaultMaxRecurrencePeriods,
+		},
+	}
+}
+
+// Every n:th day, week, month or year, counted from the start of the expanded period
+func (b *RecurrenceBuilder) WithInterval(interval int) *RecurrenceBuilder {
+	b.recurrence.interval = interval
+	return b
+}
+
+func (b *RecurrenceBuilder) WithWeekdays(weekdays ...time.Weekday) *RecurrenceBuilder {
+	b.recurrence.weekdays = append(b.recurrence.weekdays, weekdays...)
+	return b
+}
+
+func (b *RecurrenceBuilder) WithMonths(months ...time.Month) *RecurrenceBuilder {
+	b.recurrence.months = append(b.recurrence.months, months...)
+	return b
+}
+
+func (b *RecurrenceBuilder) WithTimeOfDay(start, end TimeOfDay) *RecurrenceBuilder {
+	b.recurrence.start = start
+	b.recurrence.end = end
+	return b
+}
+
+// Location in which days and times of day are interpreted, defaults to UTC
+func (b *RecurrenceBuilder) WithLocation(location *time.Location) *RecurrenceBuilder {
+	b.recurrence.location = location
+	return b
+}
+
+func (b *RecurrenceBuilder) WithMaxPeriods(maxPeriods int) *RecurrenceBuilder {
+	b.recurrence.maxPeriods = maxPeriods
+	return b
+}
+
+func (b *RecurrenceBuilder) Build() (Recurrence, error) {
+	if err := b.recurrence.validate(); err != nil {
+		return Recurrence{}, err
+	}
+
+	return b.recurrence, nil
+}
+
+func (r Recurrence) validate() error {
+	switch r.frequency {
+	case DAILY, WEEKLY, MONTHLY, YEARLY:
+	default:
+		return errors.Errorf(
+			"%w: unknown frequency '%s'",
+			puanerror.InvalidArgument,
+			r.frequency,
+		)
+	}
+
+	if r.interval < 1 {
+		return errors.Errorf(
+			"%w: interval must be positive, got %d",
+			puanerror.InvalidArgument,
+			r.interval,
+		)
+	}
+
+	if !r.start.isValid() || !r.end.isValid() || r.start.minutes() >= r.end.minutes() {
+		return errors.Errorf(
+			"%w: invalid time of day %02d:%02d-%02d:%02d",
+			puanerror.InvalidArgument,
+			r.start.hour,
+			r.start.minute,
+			r.end.hour,
+			r.end.minute,
+		)
+	}
+
+	if r.location == nil {
+		return errors.Errorf("%w: location is required", puanerror.InvalidArgument)
+	}
+
+	if r.maxPeriods < 1 {
+		return errors.Errorf(
+			"%w: max periods must be positive, got %d",
+			puanerror.InvalidArgument,
+			r.maxPeriods,
+		)
+	}
+
+	return nil
+}
+
+// Expands the recurrence into sorted periods within the given period.
+// Adjacent occurrences are merged, e.g. saturday and sunday of a weekend.
+func (r Recurrence) Expand(within Period) ([]Period, error) {
+	if !within.isBounded() {
+		return nil, errors.Errorf(
+			"%w: recurrence cannot be expanded within open-ended period %v",
+			puanerror.InvalidArgument,
+			within,
+		)
+	}
+
+	var periods []Period
+	anchor := r.startOfDay(within.from)
+	for day := anchor; day.Before(within.to); day = r.nextDay(day) {
+		if !r.occursOn(anchor, day) {
+			continue
+		}
+
+		occurrence, ok := r.occurrence(day, within)
+		if !ok {
+			continue
+		}
+
+		last := len(periods) - 1
+		if last >= 0 && !periods[last].to.Before(occurrence.from) {
+			periods[last].to = occurrence.to
+			continue
+		}
+
+		if len(periods) == r.maxPeriods {
+			return nil, errors.Errorf(
+				"%w: recurrence expands into more than %d periods within %v",
+				puanerror.InvalidArgument,
+				r.maxPeriods,
+				within,
+			)
+		}
+
+		periods = append(periods, occurrence)
+	}
+
+	return periods, nil
+}
+
+func (r Recurrence) startOfDay(t time.Time) time.Time {
+	local := t.In(r.location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, r.location)
+}
+
+func (r Recurrence) nextDay(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, r.location)
+}
+
+func (r Recurrence) occursOn(anchor, day time.Time) bool {
+	if len(r.weekdays) > 0 && !slices.Contains(r.weekdays, day.Weekday()) {
+		return false
+	}
+
+	if len(r.months) > 0 && !slices.Contains(r.months, day.Month()) {
+		return false
+	}
+
+	return r.unitsBetween(anchor, day)%r.interval == 0
+}
+
+// Number of whole days, weeks, months or years from anchor to day
+func (r Recurrence) unitsBetween(anchor, day time.Time) int {
+	switch r.frequency {
+	case WEEKLY:
+		return daysBetween(startOfWeek(anchor), day) / 7
+	case MONTHLY:
+		return (day.Year()-anchor.Year())*12 + int(day.Month()-anchor.Month())
+	case YEARLY:
+		return day.Year() - anchor.Year()
+	default:
+		return daysBetween(anchor, day)
+	}
+}
+
+// Occurrence on a day, clipped to the given period
+func (r Recurrence) occurrence(day time.Time, within Period) (Period, bool) {
+	from := r.atTimeOfDay(day, r.start)
+	to := r.atTimeOfDay(day, r.end)
+
+	if from.Before(within.from) {
+		from = within.from
+	}
+
+	if to.After(within.to) {
+		to = within.to
+	}
+
+	period, err := NewPeriod(from, to)
+	if err != nil {
+		return Period{}, false
+	}
+
+	return period, true
+}
+
+func (r Recurrence) atTimeOfDay(day time.Time, timeOfDay TimeOfDay) time.Time {
+	return time.Date(
+		day.Year(),
+		day.Month(),
+		day.Day(),
+		timeOfDay.hour,
+		timeOfDay.minute,
+		0,
+		0,
+		r.location,
+	).UTC()
+}
+
+func startOfWeek(day time.Time) time.Time {
+	// Weeks start on monday
+	offset := (int(day.Weekday()) + 6) % 7
+	return time.Date(day.Year(), day.Month(), day.Day()-offset, 0, 0, 0, 0, day.Location())
+}
+
+// Calendar days, unaffected by daylight saving time
+func daysBetween(from, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
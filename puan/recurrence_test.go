@@ -0,0 +1,254 @@
+package puan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+func Test_Recurrence_Expand_givenWeekends_shouldMergeSaturdayAndSunday(t *testing.T) {
+	recurrence, err := NewRecurrenceBuilder(WEEKLY).
+		WithWeekdays(time.Saturday, time.Sunday).
+		Build()
+	require.NoError(t, err)
+
+	within := newTestPeriod("2024-01-01T00:00:00Z", "2024-01-15T00:00:00Z")
+
+	actual, err := recurrence.Expand(within)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]Period{
+			newTestPeriod("2024-01-06T00:00:00Z", "2024-01-08T00:00:00Z"),
+			newTestPeriod("2024-01-13T00:00:00Z", "2024-01-15T00:00:00Z"),
+		},
+		actual,
+	)
+}
+
+func Test_Recurrence_Expand_givenEveryQ4_shouldReturnOnePeriodPerYear(t *testing.T) {
+	recurrence, err := NewRecurrenceBuilder(YEARLY).
+		WithMonths(time.October, time.November, time.December).
+		Build()
+	require.NoError(t, err)
+
+	within := newTestPeriod("2023-06-01T00:00:00Z", "2025-06-01T00:00:00Z")
+
+	actual, err := recurrence.Expand(within)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]Period{
+			newTestPeriod("2023-10-01T00:00:00Z", "2024-01-01T00:00:00Z"),
+			newTestPeriod("2024-10-01T00:00:00Z", "2025-01-01T00:00:00Z"),
+		},
+		actual,
+	)
+}
+
+func Test_Recurrence_Expand_givenTimeZoneWithDaylightSaving_shouldKeepLocalTimeOfDay(
+	t *testing.T,
+) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	require.NoError(t, err)
+
+	recurrence, err := NewRecurrenceBuilder(WEEKLY).
+		WithWeekdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday).
+		WithTimeOfDay(NewTimeOfDay(8, 0), NewTimeOfDay(17, 0)).
+		WithLocation(stockholm).
+		Build()
+	require.NoError(t, err)
+
+	// Daylight saving time starts sunday 2024-03-31
+	within := newTestPeriod("2024-03-29T00:00:00Z", "2024-04-02T00:00:00Z")
+
+	actual, err := recurrence.Expand(within)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]Period{
+			newTestPeriod("2024-03-29T07:00:00Z", "2024-03-29T16:00:00Z"),
+			newTestPeriod("2024-04-01T06:00:00Z", "2024-04-01T15:00:00Z"),
+		},
+		actual,
+	)
+}
+
+func Test_Recurrence_Expand_givenInterval_shouldSkipWeeks(t *testing.T) {
+	recurrence, err := NewRecurrenceBuilder(WEEKLY).
+		WithInterval(2).
+		WithWeekdays(time.Monday).
+		Build()
+	require.NoError(t, err)
+
+	within := newTestPeriod("2024-01-01T00:00:00Z", "2024-01-29T00:00:00Z")
+
+	actual, err := recurrence.Expand(within)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]Period{
+			newTestPeriod("2024-01-01T00:00:00Z", "2024-01-02T00:00:00Z"),
+			newTestPeriod("2024-01-15T00:00:00Z", "2024-01-16T00:00:00Z"),
+		},
+		actual,
+	)
+}
+
+func Test_Recurrence_Expand_givenTooManyPeriods_shouldReturnError(t *testing.T) {
+	recurrence, err := NewRecurrenceBuilder(DAILY).
+		WithTimeOfDay(NewTimeOfDay(8, 0), NewTimeOfDay(17, 0)).
+		WithMaxPeriods(10).
+		Build()
+	require.NoError(t, err)
+
+	within := newTestPeriod("2024-01-01T00:00:00Z", "2025-01-01T00:00:00Z")
+
+	_, err = recurrence.Expand(within)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_RecurrenceBuilder_Build_givenInvalidRecurrence_shouldReturnError(t *testing.T) {
+	builders := map[string]*RecurrenceBuilder{
+		"unknown frequency": NewRecurrenceBuilder("HOURLY"),
+		"zero interval":     NewRecurrenceBuilder(DAILY).WithInterval(0),
+		"start after end": NewRecurrenceBuilder(DAILY).
+			WithTimeOfDay(NewTimeOfDay(17, 0), NewTimeOfDay(8, 0)),
+		"invalid minute": NewRecurrenceBuilder(DAILY).
+			WithTimeOfDay(NewTimeOfDay(8, 60), NewTimeOfDay(17, 0)),
+		"nil location":    NewRecurrenceBuilder(DAILY).WithLocation(nil),
+		"zero max period": NewRecurrenceBuilder(DAILY).WithMaxPeriods(0),
+	}
+
+	for name, builder := range builders {
+		t.Run(name, func(t *testing.T) {
+			_, err := builder.Build()
+			assert.ErrorIs(t, err, puanerror.InvalidArgument)
+		})
+	}
+}
+
+func Test_RulesetCreator_AssumeInRecurrence_givenTimeDisabled_shouldReturnError(
+	t *testing.T,
+) {
+	recurrence, err := NewRecurrenceBuilder(DAILY).Build()
+	require.NoError(t, err)
+
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x")
+
+	err = creator.AssumeInRecurrence("x", recurrence)
+
+	assert.ErrorIs(t, err, puanerror.InvalidOperation)
+}
+
+func Test_RulesetCreator_AssumeInRecurrence_shouldAssumeInEachPeriod(t *testing.T) {
+	recurrence, err := NewRecurrenceBuilder(WEEKLY).
+		WithWeekdays(time.Saturday, time.Sunday).
+		Build()
+	require.NoError(t, err)
+
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-15T00:00:00Z"),
+	)
+
+	err = creator.AssumeInRecurrence("x", recurrence)
+	require.NoError(t, err)
+
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]Period{
+			newTestPeriod("2024-01-06T00:00:00Z", "2024-01-08T00:00:00Z"),
+			newTestPeriod("2024-01-13T00:00:00Z", "2024-01-15T00:00:00Z"),
+		},
+		ruleset.TimeBoundAssumedVariables().periods(),
+	)
+}
+
+func Test_RulesetCreator_ForbidRecurrence_givenOverlappingOccurrence_shouldForbidNothing(
+	t *testing.T,
+) {
+	recurrence, err := NewRecurrenceBuilder(WEEKLY).
+		WithWeekdays(time.Saturday, time.Sunday).
+		Build()
+	require.NoError(t, err)
+
+	creator := NewRulesetCreator()
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-15T00:00:00Z"),
+	)
+	_ = creator.ForbidPeriod(
+		newTestTime("2024-01-14T00:00:00Z"),
+		newTestTime("2024-01-15T00:00:00Z"),
+	)
+
+	err = creator.ForbidRecurrence(recurrence)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+	assert.Len(t, creator.forbiddenPeriods, 1)
+}
+
+func Test_RulesetCreator_AssumeInRecurrence_givenTooManyPeriodsInTotal_shouldReturnError(
+	t *testing.T,
+) {
+	weekends, err := NewRecurrenceBuilder(WEEKLY).
+		WithWeekdays(time.Saturday, time.Sunday).
+		Build()
+	require.NoError(t, err)
+	mondays, err := NewRecurrenceBuilder(WEEKLY).
+		WithWeekdays(time.Monday).
+		Build()
+	require.NoError(t, err)
+
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-15T00:00:00Z"),
+	)
+	require.NoError(t, creator.LimitRecurrencePeriods(3))
+	require.NoError(t, creator.AssumeInRecurrence("x", weekends))
+
+	err = creator.AssumeInRecurrence("y", mondays)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+	assert.NotContains(t, creator.timeBoundAssumedVariables.ids(), "y")
+}
+
+func Test_Recurrence_Expand_givenOpenEndedPeriod_shouldReturnError(t *testing.T) {
+	recurrence, err := NewRecurrenceBuilder(DAILY).Build()
+	require.NoError(t, err)
+
+	from := newTestTime("2024-01-01T00:00:00Z")
+	within, err := NewOpenPeriod(&from, nil)
+	require.NoError(t, err)
+
+	_, err = recurrence.Expand(within)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func newTestPeriod(from, to string) Period {
+	period, err := NewPeriod(newTestTime(from), newTestTime(to))
+	if err != nil {
+		panic(err)
+	}
+
+	return period
+}
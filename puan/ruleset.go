@@ -1,6 +1,7 @@
 package puan
 
 import (
+	"slices"
 	"time"
 
 	"github.com/go-errors/errors"
@@ -18,6 +19,35 @@ type Ruleset struct {
 	independentVariables []string
 	preferredVariables   []string
 	periodVariables      TimeBoundVariables
+
+	timeBoundAssumedVariables TimeBoundVariables
+
+	// Set when the ruleset is created, shared by copies of the value.
+	// Not copied by copy, since copies are modified for queries,
+	// and dropped on any change, see resetDerived.
+	components *rulesetComponents
+	// Connects implied changes to their causes in DiffSolutions,
+	// kept and dropped as the components
+	variableGraph *variableGraph
+	// Identifies the ruleset as created in the default solutions
+	// cached by solution creators, zero once the ruleset is changed
+	cacheID uint64
+}
+
+// Sparse matrix in coordinate format, i.e. the value at
+// (rows[i], columns[i]) is values[i] and all other values are zero
+type SparseMatrix = pldag.SparseMatrix
+
+func NewSparseMatrix(
+	rows, columns, values []int,
+	nrOfRows, nrOfColumns int,
+) SparseMatrix {
+	return pldag.NewSparseMatrix(
+		rows,
+		columns,
+		values,
+		pldag.NewShape(nrOfRows, nrOfColumns),
+	)
 }
 
 // For when creating a rule set from a serialized representation
@@ -30,8 +60,71 @@ func HydrateRuleSet(
 	selectableVariables []string,
 	preferredVariables []string,
 	periodVariables TimeBoundVariables,
+	options ...HydrationOption,
 ) (Ruleset, error) {
 	polyhedron := pldag.NewPolyhedron(aMatrix, bVector)
+	return hydrateRuleSet(
+		polyhedron,
+		dependentVariables,
+		independentVariables,
+		selectableVariables,
+		preferredVariables,
+		periodVariables,
+		options,
+	)
+}
+
+// As HydrateRuleSet, from a sparse representation,
+// e.g. from Polyhedron().SparseMatrix() and Polyhedron().B()
+func HydrateSparseRuleSet(
+	aMatrix SparseMatrix,
+	bVector []int,
+	dependentVariables []string,
+	independentVariables []string,
+	selectableVariables []string,
+	preferredVariables []string,
+	periodVariables TimeBoundVariables,
+	options ...HydrationOption,
+) (Ruleset, error) {
+	polyhedron, err := pldag.NewSparsePolyhedron(aMatrix, bVector)
+	if err != nil {
+		return Ruleset{}, err
+	}
+
+	return hydrateRuleSet(
+		polyhedron,
+		dependentVariables,
+		independentVariables,
+		selectableVariables,
+		preferredVariables,
+		periodVariables,
+		options,
+	)
+}
+
+func hydrateRuleSet(
+	polyhedron *pldag.Polyhedron,
+	dependentVariables []string,
+	independentVariables []string,
+	selectableVariables []string,
+	preferredVariables []string,
+	periodVariables TimeBoundVariables,
+	options []HydrationOption,
+) (Ruleset, error) {
+	var hydration hydration
+	for _, option := range options {
+		option(&hydration)
+	}
+
+	if polyhedron.NrOfColumns() > len(dependentVariables) {
+		return Ruleset{}, errors.Errorf(
+			"%w: polyhedron has %d columns but there are %d dependent variables",
+			puanerror.InvalidArgument,
+			polyhedron.NrOfColumns(),
+			len(dependentVariables),
+		)
+	}
+
 	return newRuleset(
 		polyhedron,
 		selectableVariables,
@@ -39,9 +132,26 @@ func HydrateRuleSet(
 		independentVariables,
 		preferredVariables,
 		periodVariables,
+		hydration.timeBoundAssumedVariables,
 	)
 }
 
+// Optional part of a serialized rule set, see HydrateRuleSet
+type HydrationOption func(*hydration)
+
+type hydration struct {
+	timeBoundAssumedVariables TimeBoundVariables
+}
+
+// Variables assumed in periods, from TimeBoundAssumedVariables, with the
+// IDs of their constraints. Without them, AnalyzePeriodFeasibility reports
+// no conflicting assumptions.
+func WithTimeBoundAssumedVariables(variables TimeBoundVariables) HydrationOption {
+	return func(h *hydration) {
+		h.timeBoundAssumedVariables = variables
+	}
+}
+
 func newRuleset(
 	polyhedron *pldag.Polyhedron,
 	selectableVariables []string,
@@ -49,6 +159,7 @@ func newRuleset(
 	independentVariables []string,
 	preferredVariables []string,
 	periodVariables TimeBoundVariables,
+	timeBoundAssumedVariables TimeBoundVariables,
 ) (Ruleset, error) {
 	if polyhedron == nil {
 		return Ruleset{}, errors.Errorf(
@@ -68,13 +179,33 @@ func newRuleset(
 		return Ruleset{}, err
 	}
 
+	if !utils.ContainsAll(dependentVariables, timeBoundAssumedVariables.ids()) {
+		return Ruleset{}, errors.Errorf(
+			"%w: time bound assumed variables must exist in dependent variables",
+			puanerror.InvalidArgument,
+		)
+	}
+
 	return Ruleset{
-		polyhedron:           polyhedron,
-		selectableVariables:  selectableVariables,
-		dependentVariables:   dependentVariables,
-		independentVariables: independentVariables,
-		preferredVariables:   preferredVariables,
-		periodVariables:      periodVariables,
+		polyhedron:                polyhedron,
+		selectableVariables:       selectableVariables,
+		dependentVariables:        dependentVariables,
+		independentVariables:      independentVariables,
+		preferredVariables:        preferredVariables,
+		periodVariables:           periodVariables,
+		timeBoundAssumedVariables: timeBoundAssumedVariables,
+		components: newRulesetComponents(
+			polyhedron,
+			dependentVariables,
+			selectableVariables,
+		),
+		variableGraph: newVariableGraph(
+			polyhedron,
+			dependentVariables,
+			selectableVariables,
+			periodVariables,
+		),
+		cacheID: newRulesetCacheID(),
 	}, nil
 }
 
@@ -145,6 +276,12 @@ func (r *Ruleset) PeriodVariables() TimeBoundVariables {
 	return r.periodVariables
 }
 
+// Variables assumed during a part of the ruleset period,
+// see RulesetCreator.AssumeInPeriod
+func (r *Ruleset) TimeBoundAssumedVariables() TimeBoundVariables {
+	return r.timeBoundAssumedVariables
+}
+
 func (r *Ruleset) dependentSelectableVariables() []string {
 	return utils.Without(r.selectableVariables, r.independentVariables)
 }
@@ -194,17 +331,7 @@ func (r *Ruleset) FindPeriodInSolution(solution Solution) (Period, error) {
 }
 
 func (r *Ruleset) copy() Ruleset {
-	aMatrix := r.polyhedron.A()
-	aMatrixCopy := make([][]int, len(r.polyhedron.A()))
-	for i := range aMatrix {
-		aMatrixCopy[i] = make([]int, len(aMatrix[i]))
-		copy(aMatrixCopy[i], aMatrix[i])
-	}
-
-	bVector := make([]int, len(r.polyhedron.B()))
-	copy(bVector, r.polyhedron.B())
-
-	polyhedron := pldag.NewPolyhedron(aMatrixCopy, bVector)
+	polyhedron := r.polyhedron.Copy()
 
 	dependantVariableIDs := make([]string, len(r.dependentVariables))
 	copy(dependantVariableIDs, r.dependentVariables)
@@ -221,16 +348,28 @@ func (r *Ruleset) copy() Ruleset {
 	periodVariables := make([]TimeBoundVariable, len(r.periodVariables))
 	copy(periodVariables, r.periodVariables)
 
+	timeBoundAssumedVariables := make([]TimeBoundVariable, len(r.timeBoundAssumedVariables))
+	copy(timeBoundAssumedVariables, r.timeBoundAssumedVariables)
+
 	return Ruleset{
-		polyhedron:           polyhedron,
-		selectableVariables:  selectableVariables,
-		dependentVariables:   dependantVariableIDs,
-		independentVariables: independentVariablesIDs,
-		preferredVariables:   preferredIDs,
-		periodVariables:      periodVariables,
+		polyhedron:                polyhedron,
+		selectableVariables:       selectableVariables,
+		dependentVariables:        dependantVariableIDs,
+		independentVariables:      independentVariablesIDs,
+		preferredVariables:        preferredIDs,
+		periodVariables:           periodVariables,
+		timeBoundAssumedVariables: timeBoundAssumedVariables,
 	}
 }
 
+// Drops the components, variable graph and cached default solutions of the ruleset
+// as created, which no longer hold once the ruleset is changed
+func (r *Ruleset) resetDerived() {
+	r.components = nil
+	r.variableGraph = nil
+	r.cacheID = 0
+}
+
 func (r *Ruleset) modifyForQuery(
 	selections Selections,
 	from *time.Time,
@@ -242,6 +381,10 @@ func (r *Ruleset) modifyForQuery(
 		return Ruleset{}, err
 	}
 
+	if err := ruleset.assumeHardSelections(selections); err != nil {
+		return Ruleset{}, err
+	}
+
 	if from != nil {
 		err := ruleset.forbidPassedPeriods(*from)
 		if err != nil {
@@ -266,8 +409,7 @@ func (r *Ruleset) setCompositeSelectionConstraints(
 ) error {
 	for _, selection := range selections {
 		if selection.IsComposite() {
-			ids := selection.IDs()
-			if err := r.setCompositeSelectionConstraint(ids); err != nil {
+			if err := r.setCompositeSelection(selection); err != nil {
 				return err
 			}
 		}
@@ -275,6 +417,28 @@ func (r *Ruleset) setCompositeSelectionConstraints(
 	return nil
 }
 
+// Hard selections are assumed instead of weighted,
+// like a prioritised solution in split solving
+func (r *Ruleset) assumeHardSelections(selections Selections) error {
+	for _, selection := range selections.prepareForQuery().hardSelections() {
+		id, err := r.getWeightSelectionID(selection)
+		if err != nil {
+			return err
+		}
+
+		if selection.action == REMOVE {
+			err = r.assumeNot(id)
+		} else {
+			err = r.assume(id)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *Ruleset) newWeightSelections(selections Selections) (weights.Selections, error) {
 	weightSelections := make(weights.Selections, len(selections))
 	for i, selection := range selections {
@@ -305,7 +469,7 @@ func (r *Ruleset) newWeightSelection(selection Selection) (weights.Selection, er
 
 func (r *Ruleset) getWeightSelectionID(selection Selection) (string, error) {
 	if selection.IsComposite() {
-		constraint, err := newCompositeSelectionConstraint(selection.IDs())
+		constraint, err := newIdentifyingConstraint(selection)
 		if err != nil {
 			return "", err
 		}
@@ -324,28 +488,94 @@ func (r *Ruleset) getWeightSelectionID(selection Selection) (string, error) {
 	return selection.id, nil
 }
 
-func (r *Ruleset) setCompositeSelectionConstraint(ids []string) error {
-	constraint, err := newCompositeSelectionConstraint(ids)
+func newCompositeSelectionConstraint(ids []string) (pldag.Constraint, error) {
+	dedupedIDs := utils.Dedupe(ids)
+	constraint, err := pldag.NewAtLeastConstraint(dedupedIDs, len(dedupedIDs))
 	if err != nil {
-		return err
+		return pldag.Constraint{}, err
 	}
 
-	err = r.setConstraintIfNotExist(constraint)
+	return constraint, nil
+}
+
+func (r *Ruleset) setCompositeSelection(selection Selection) error {
+	constraints, err := newCompositeSelectionConstraints(selection)
 	if err != nil {
 		return err
 	}
 
+	for _, constraint := range constraints {
+		err = r.setConstraintIfNotExist(constraint)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func newCompositeSelectionConstraint(ids []string) (pldag.Constraint, error) {
-	dedupedIDs := utils.Dedupe(ids)
-	constraint, err := pldag.NewAtLeastConstraint(dedupedIDs, len(dedupedIDs))
+// Constraint identifying the composite selection
+func newIdentifyingConstraint(selection Selection) (pldag.Constraint, error) {
+	constraints, err := newCompositeSelectionConstraints(selection)
 	if err != nil {
 		return pldag.Constraint{}, err
 	}
 
-	return constraint, nil
+	return constraints[len(constraints)-1], nil
+}
+
+// Constraints of a composite selection, those of nested selections first.
+// The last constraint identifies the composite selection.
+func newCompositeSelectionConstraints(selection Selection) (pldag.Constraints, error) {
+	var constraints pldag.Constraints
+
+	subIDs := slices.Clone(selection.subSelectionIDs)
+	for _, nested := range selection.nestedSelections {
+		if !nested.IsComposite() {
+			subIDs = append(subIDs, nested.id)
+			continue
+		}
+
+		nestedConstraints, err := newCompositeSelectionConstraints(nested)
+		if err != nil {
+			return nil, err
+		}
+
+		constraints = append(constraints, nestedConstraints...)
+		subIDs = append(subIDs, nestedConstraints[len(nestedConstraints)-1].ID())
+	}
+
+	ids := []string{selection.id}
+	switch selection.compositeKind {
+	case ANY_OF:
+		anyOf, err := pldag.NewAtLeastConstraint(utils.Dedupe(subIDs), 1)
+		if err != nil {
+			return nil, err
+		}
+
+		constraints = append(constraints, anyOf)
+		ids = append(ids, anyOf.ID())
+	case EXACTLY:
+		ids = append(ids, subIDs...)
+		if len(selection.siblingIDs) > 0 {
+			noneOf, err := pldag.NewAtMostConstraint(utils.Dedupe(selection.siblingIDs), 0)
+			if err != nil {
+				return nil, err
+			}
+
+			constraints = append(constraints, noneOf)
+			ids = append(ids, noneOf.ID())
+		}
+	default:
+		ids = append(ids, subIDs...)
+	}
+
+	allOf, err := newCompositeSelectionConstraint(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(constraints, allOf), nil
 }
 
 func (r *Ruleset) setConstraintIfNotExist(constraint pldag.Constraint) error {
@@ -361,6 +591,7 @@ func (r *Ruleset) constraintExists(constraint pldag.Constraint) bool {
 }
 
 func (r *Ruleset) setConstraint(constraint pldag.Constraint) error {
+	r.resetDerived()
 	r.polyhedron.AddEmptyColumn()
 	r.dependentVariables = append(r.dependentVariables, constraint.ID())
 
@@ -386,28 +617,79 @@ func (r *Ruleset) setAuxiliaryConstraint(constraint pldag.AuxiliaryConstraint) e
 		return err
 	}
 
-	r.polyhedron.Extend(row, constraint.Bias())
+	r.resetDerived()
+	r.polyhedron.ExtendSparse(row, constraint.Bias())
 
 	return nil
 }
 
-func (r *Ruleset) newRow(coefficients pldag.Coefficients) ([]int, error) {
-	row := make([]int, len(r.dependentVariables))
+func (r *Ruleset) newRow(coefficients pldag.Coefficients) (pldag.SparseRow, error) {
+	columns := make([]int, 0, len(coefficients))
+	values := make([]int, 0, len(coefficients))
 
 	for id, value := range coefficients {
 		idIndex, err := utils.IndexOf(r.dependentVariables, id)
 		if err != nil {
-			return nil, errors.Errorf(
+			return pldag.SparseRow{}, errors.Errorf(
 				"%w: variable %s not found in dependent variables",
 				puanerror.NotFound,
 				id,
 			)
 		}
 
-		row[idIndex] = value
+		columns = append(columns, idIndex)
+		values = append(values, value)
+	}
+
+	return pldag.NewSparseRow(columns, values), nil
+}
+
+// Keeps the objective value of the weights at least that of the solution,
+// used when solving weights in tiers
+func (r *Ruleset) setObjectiveBound(weights weights.Weights, solution Solution) error {
+	coefficients := make(pldag.Coefficients)
+	value := 0
+	for id, weight := range weights {
+		if weight == 0 {
+			continue
+		}
+
+		coefficients[id] = -weight
+		value += weight * solution[id]
 	}
 
-	return row, nil
+	if len(coefficients) == 0 {
+		return nil
+	}
+
+	row, err := r.newRow(coefficients)
+	if err != nil {
+		return err
+	}
+
+	r.resetDerived()
+	r.polyhedron.ExtendSparse(row, pldag.Bias(-value))
+
+	return nil
+}
+
+// Periods forbidden by the from and to of a query
+func (r *Ruleset) findForbiddenPeriodIDs(from *time.Time, to *time.Time) []string {
+	var forbiddenPeriodIDs []string
+	if from != nil {
+		forbiddenPeriodIDs = append(
+			forbiddenPeriodIDs,
+			r.periodVariables.earlierThan(*from).ids()...,
+		)
+	}
+	if to != nil {
+		forbiddenPeriodIDs = append(
+			forbiddenPeriodIDs,
+			r.periodVariables.laterThan(*to).ids()...,
+		)
+	}
+
+	return forbiddenPeriodIDs
 }
 
 func (r *Ruleset) forbidPassedPeriods(from time.Time) error {
@@ -460,7 +742,7 @@ func (r *Ruleset) isValidFromTime(from *time.Time) bool {
 	}
 
 	for _, periodVariable := range r.periodVariables {
-		isValid := !from.After(periodVariable.period.To())
+		isValid := newTimeEdge(*from).compare(periodVariable.period.end()) <= 0
 		if isValid {
 			return true
 		}
@@ -472,3 +754,54 @@ func (r *Ruleset) isValidFromTime(from *time.Time) bool {
 func (r *Ruleset) timeDisabled() bool {
 	return len(r.periodVariables) == 0
 }
+
+// Removes the assumption of the time-bound constraints for the given variables,
+// i.e. the variables are no longer assumed in their periods. Variables
+// assumed twice in the same periods share their constraint.
+func (r *Ruleset) relaxTimeBoundAssumptions(variables TimeBoundVariables) error {
+	var rowIndices []int
+	for _, variable := range variables {
+		rowIndex, err := r.findTimeBoundAssumptionRow(variable)
+		if err != nil {
+			return err
+		}
+
+		rowIndices = append(rowIndices, rowIndex)
+	}
+
+	r.resetDerived()
+	r.polyhedron.RemoveRows(utils.Dedupe(rowIndices)...)
+
+	return nil
+}
+
+func (r *Ruleset) findTimeBoundAssumptionRow(variable TimeBoundVariable) (int, error) {
+	if variable.constraintID == "" {
+		return -1, errors.Errorf(
+			"%w: time bound assumption of %s cannot be relaxed, "+
+				"see RulesetCreator.EnablePeriodFeasibilityAnalysis",
+			puanerror.InvalidOperation,
+			variable.variable,
+		)
+	}
+
+	column, err := utils.IndexOf(r.dependentVariables, variable.constraintID)
+	if err != nil {
+		return -1, errors.Errorf(
+			"%w: time bound constraint for %s not found in dependent variables",
+			puanerror.NotFound,
+			variable.variable,
+		)
+	}
+
+	rowIndex, found := r.polyhedron.FindAssumedRow(column)
+	if !found {
+		return -1, errors.Errorf(
+			"%w: time bound constraint for %s is not assumed",
+			puanerror.NotFound,
+			variable.variable,
+		)
+	}
+
+	return rowIndex, nil
+}
@@ -0,0 +1,282 @@
+package puan
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/pldag"
+	"github.com/ourstudio-se/puan-sdk-go/internal/utils"
+)
+
+// Independent sub-problems of the dependent variables, found when the
+// ruleset is created. Variables fixed by the assumptions of the ruleset
+// belong to no component, since they take the same value in all solutions.
+type rulesetComponents struct {
+	fixed      Solution
+	components []*rulesetComponent
+	// Index of the component of each variable not fixed
+	byVariable map[string]int
+}
+
+// Connected variables, with the rows of the polyhedron constraining them
+type rulesetComponent struct {
+	polyhedron *pldag.Polyhedron
+	variables  []string
+	selectable []string
+}
+
+// Splits the polyhedron into sub-polyhedra of connected variables.
+// Returns nil if the assumptions cannot be met, leaving the error
+// to the solver.
+func newRulesetComponents(
+	polyhedron *pldag.Polyhedron,
+	dependentVariables []string,
+	selectableVariables []string,
+) *rulesetComponents {
+	fixed, feasible := findFixedColumns(polyhedron)
+	if !feasible {
+		return nil
+	}
+
+	connected := utils.NewDisjointSet[int]()
+	var rows []pldag.SparseRow
+	var biases []int
+	for i, row := range polyhedron.Rows() {
+		reduced, bias := reduceRow(row, polyhedron.B()[i], fixed)
+		if isRedundantRow(reduced.Values(), bias) {
+			continue
+		}
+
+		connected.Union(reduced.Columns()...)
+		rows = append(rows, reduced)
+		biases = append(biases, bias)
+	}
+
+	components := &rulesetComponents{
+		fixed:      make(Solution),
+		byVariable: make(map[string]int),
+	}
+
+	indexByRoot := make(map[int]int)
+	localColumns := make(map[int]int)
+	for column, variable := range dependentVariables {
+		if value, isFixed := fixed[column]; isFixed {
+			components.fixed[variable] = value
+			continue
+		}
+
+		root := connected.Find(column)
+		index, exists := indexByRoot[root]
+		if !exists {
+			index = len(components.components)
+			indexByRoot[root] = index
+			components.components = append(components.components, &rulesetComponent{
+				polyhedron: pldag.NewPolyhedron(nil, nil),
+			})
+		}
+
+		component := components.components[index]
+		localColumns[column] = len(component.variables)
+		component.variables = append(component.variables, variable)
+		component.polyhedron.AddEmptyColumn()
+		components.byVariable[variable] = index
+	}
+
+	for i, row := range rows {
+		if len(row.Columns()) == 0 {
+			continue
+		}
+
+		columns := make([]int, len(row.Columns()))
+		for j, column := range row.Columns() {
+			columns[j] = localColumns[column]
+		}
+
+		component := components.components[indexByRoot[connected.Find(row.Columns()[0])]]
+		component.polyhedron.ExtendSparse(
+			pldag.NewSparseRow(columns, row.Values()),
+			pldag.Bias(biases[i]),
+		)
+	}
+
+	for _, component := range components.components {
+		component.selectable = utils.Intersect(component.variables, selectableVariables)
+	}
+
+	return components
+}
+
+// Indices of the components of the ruleset with variables of the
+// selections, or with preferred, period or time-bound variables, whose
+// weights depend on the query. Returns false if the ruleset has no
+// components or if the query touches all of them.
+func (query SolutionQuery) findTouchedComponents() ([]int, bool) {
+	components := query.ruleset.components
+	if components == nil {
+		return nil, false
+	}
+
+	var variables []string
+	for _, selection := range query.selections {
+		variables = append(variables, selection.connectedIDs()...)
+	}
+	variables = append(variables, query.ruleset.preferredVariables...)
+	variables = append(variables, query.ruleset.periodVariables.ids()...)
+	variables = append(variables, query.ruleset.timeBoundAssumedVariables.ids()...)
+
+	var touched []int
+	for _, variable := range variables {
+		index, exists := components.byVariable[variable]
+		if exists && !slices.Contains(touched, index) {
+			touched = append(touched, index)
+		}
+	}
+
+	slices.Sort(touched)
+
+	return touched, len(touched) < len(components.components)
+}
+
+// Ruleset of the touched components and the fixed variables,
+// without any components of its own
+func (r *Ruleset) newTouchedRuleset(touched []int) Ruleset {
+	fixedVariables := utils.Sorted(slices.Collect(maps.Keys(r.components.fixed)))
+
+	polyhedron := pldag.NewPolyhedron(nil, nil)
+	var dependentVariables []string
+	for _, variable := range fixedVariables {
+		polyhedron.AddEmptyColumn()
+		column := len(dependentVariables)
+		dependentVariables = append(dependentVariables, variable)
+
+		if r.components.fixed[variable] == 1 {
+			polyhedron.ExtendSparse(pldag.NewSparseRow([]int{column}, []int{-1}), -1)
+		} else {
+			polyhedron.ExtendSparse(pldag.NewSparseRow([]int{column}, []int{1}), 0)
+		}
+	}
+
+	for _, index := range touched {
+		component := r.components.components[index]
+		offset := len(dependentVariables)
+		for range component.variables {
+			polyhedron.AddEmptyColumn()
+		}
+		dependentVariables = append(dependentVariables, component.variables...)
+
+		for i, row := range component.polyhedron.Rows() {
+			columns := make([]int, len(row.Columns()))
+			for j, column := range row.Columns() {
+				columns[j] = column + offset
+			}
+
+			polyhedron.ExtendSparse(
+				pldag.NewSparseRow(columns, row.Values()),
+				pldag.Bias(component.polyhedron.B()[i]),
+			)
+		}
+	}
+
+	variables := append(slices.Clone(dependentVariables), r.independentVariables...)
+
+	return Ruleset{
+		polyhedron:                polyhedron,
+		selectableVariables:       utils.Intersect(r.selectableVariables, variables),
+		dependentVariables:        dependentVariables,
+		independentVariables:      r.independentVariables,
+		preferredVariables:        r.preferredVariables,
+		periodVariables:           r.periodVariables,
+		timeBoundAssumedVariables: r.timeBoundAssumedVariables,
+	}
+}
+
+// Solves only the touched components of the ruleset, and fills the
+// others with their default solutions, i.e. without selections
+func (c *SolutionCreator) calculateTouchedDependentSolution(
+	query SolutionQuery,
+	touched []int,
+) (Solution, error) {
+	solution, err := c.calculateFixedAndTouchedSolution(query, touched)
+	if err != nil {
+		return Solution{}, err
+	}
+
+	for i, component := range query.ruleset.components.components {
+		if slices.Contains(touched, i) {
+			continue
+		}
+
+		defaults, err := c.findComponentDefaults(query.ruleset, i, component)
+		if err != nil {
+			return Solution{}, err
+		}
+
+		solution = solution.merge(defaults)
+	}
+
+	return solution, nil
+}
+
+// Without selections, forbidden periods or touched components,
+// all variables left are fixed
+func (c *SolutionCreator) calculateFixedAndTouchedSolution(
+	query SolutionQuery,
+	touched []int,
+) (Solution, error) {
+	forbidden := query.ruleset.findForbiddenPeriodIDs(query.from, query.to)
+	if len(touched) == 0 && len(query.selections) == 0 && len(forbidden) == 0 {
+		fixed := query.ruleset.components.fixed
+		return query.ruleset.RemoveSupportVariables(fixed), nil
+	}
+
+	builder := NewSolutionQueryBuilder().fromQuery(query)
+	if query.prepared != nil {
+		builder.WithPreparedRuleset(query.prepared.forTouchedComponents(touched))
+	} else {
+		builder.WithRuleset(query.ruleset.newTouchedRuleset(touched))
+	}
+
+	return c.calculateDependentSolution(builder.Build())
+}
+
+// Solution of the selectable variables of the component without
+// selections, solved once and then cached in the solution creator
+func (c *SolutionCreator) findComponentDefaults(
+	ruleset Ruleset,
+	index int,
+	component *rulesetComponent,
+) (Solution, error) {
+	key := "component:" +
+		strconv.FormatUint(ruleset.cacheID, 10) + ":" +
+		strconv.Itoa(index)
+	if defaults, ok := c.defaultSolutions.Get(key); ok {
+		return defaults, nil
+	}
+
+	componentRuleset := Ruleset{
+		polyhedron:          component.polyhedron,
+		selectableVariables: component.selectable,
+		dependentVariables:  component.variables,
+	}
+
+	weights, err := newWeights(componentRuleset, nil, periodWeighting{})
+	if err != nil {
+		return nil, err
+	}
+
+	solverQuery := NewSolverQuery(
+		componentRuleset.polyhedron,
+		componentRuleset.dependentVariables,
+		weights,
+	)
+	solution, err := c.Solve(solverQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := solution.Extract(component.selectable...)
+	c.defaultSolutions.Add(key, defaults)
+
+	return defaults, nil
+}
@@ -0,0 +1,227 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+func Test_RulesetCreator_Create_givenUnconnectedRules_shouldSplitIntoComponents(
+	t *testing.T,
+) {
+	// a XOR b and c XOR d, without any rule between them
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("a", "b", "c", "d")
+	abXor, _ := creator.SetXor("a", "b")
+	cdXor, _ := creator.SetXor("c", "d")
+	_ = creator.Assume(abXor, cdXor)
+	ruleset, _ := creator.Create()
+
+	require.NotNil(t, ruleset.components)
+	require.Len(t, ruleset.components.components, 2)
+	assert.Equal(t, []string{"a", "b"}, ruleset.components.components[0].variables)
+	assert.Equal(t, []string{"c", "d"}, ruleset.components.components[1].variables)
+	for _, variable := range []string{"a", "b", "c", "d"} {
+		assert.NotContains(t, ruleset.components.fixed, variable)
+	}
+	for _, value := range ruleset.components.fixed {
+		assert.Equal(t, 1, value)
+	}
+}
+
+func Test_Ruleset_copy_shouldNotKeepComponents(t *testing.T) {
+	// a XOR b and c XOR d, without any rule between them
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("a", "b", "c", "d")
+	abXor, _ := creator.SetXor("a", "b")
+	cdXor, _ := creator.SetXor("c", "d")
+	_ = creator.Assume(abXor, cdXor)
+	ruleset, _ := creator.Create()
+
+	ccopy := ruleset.copy()
+
+	assert.Nil(t, ccopy.components)
+}
+
+func Test_SolutionQuery_findTouchedComponents(t *testing.T) {
+	// a XOR b and c XOR d, without any rule between them
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("a", "b", "c", "d")
+	abXor, _ := creator.SetXor("a", "b")
+	cdXor, _ := creator.SetXor("c", "d")
+	_ = creator.Assume(abXor, cdXor)
+	ruleset, _ := creator.Create()
+
+	query := NewSolutionQueryBuilder().
+		WithRuleset(ruleset).
+		WithSelections(Selections{NewSelectionBuilder("d").Build()}).
+		Build()
+
+	touched, decomposable := query.findTouchedComponents()
+
+	assert.True(t, decomposable)
+	assert.Equal(t, []int{1}, touched)
+}
+
+func Test_SolutionQuery_findTouchedComponents_givenAllTouched_shouldNotBeDecomposable(
+	t *testing.T,
+) {
+	// a XOR b and c XOR d, without any rule between them
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("a", "b", "c", "d")
+	abXor, _ := creator.SetXor("a", "b")
+	cdXor, _ := creator.SetXor("c", "d")
+	_ = creator.Assume(abXor, cdXor)
+	ruleset, _ := creator.Create()
+
+	query := NewSolutionQueryBuilder().
+		WithRuleset(ruleset).
+		WithSelections(Selections{
+			NewSelectionBuilder("a").WithSubSelectionID("d").Build(),
+		}).
+		Build()
+
+	_, decomposable := query.findTouchedComponents()
+
+	assert.False(t, decomposable)
+}
+
+func Test_SolutionCreator_Create_givenUntouchedComponent_shouldUseCachedDefaults(
+	t *testing.T,
+) {
+	// a XOR b and c XOR d, without any rule between them
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("a", "b", "c", "d")
+	abXor, _ := creator.SetXor("a", "b")
+	cdXor, _ := creator.SetXor("c", "d")
+	_ = creator.Assume(abXor, cdXor)
+	ruleset, _ := creator.Create()
+
+	client := &countingSolverClient{}
+	solutionCreator := NewSolutionCreator(client)
+
+	first, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("b").Build()}).
+			Build(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, Solution{"a": 0, "b": 1, "c": 1, "d": 0}, first.Solution())
+	assert.Equal(t, 2, client.solveCalls)
+
+	second, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("a").Build()}).
+			Build(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, Solution{"a": 1, "b": 0, "c": 1, "d": 0}, second.Solution())
+	assert.Equal(t, 3, client.solveCalls)
+}
+
+func Test_SolutionCreator_Create_givenPreparedRuleset_shouldSolveTouchedComponents(
+	t *testing.T,
+) {
+	// a XOR b and c XOR d, without any rule between them
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("a", "b", "c", "d")
+	abXor, _ := creator.SetXor("a", "b")
+	cdXor, _ := creator.SetXor("c", "d")
+	_ = creator.Assume(abXor, cdXor)
+	ruleset, _ := creator.Create()
+
+	prepared := NewPreparedRuleset(ruleset, DefaultPreparedRulesetCapacity)
+	query := NewSolutionQueryBuilder().
+		WithPreparedRuleset(prepared).
+		WithSelections(Selections{NewSelectionBuilder("b").Build()}).
+		Build()
+
+	touched, decomposable := query.findTouchedComponents()
+	require.True(t, decomposable)
+
+	envelope, err := NewSolutionCreator(bruteForceSolverClient{}).Create(query)
+
+	require.NoError(t, err)
+	assert.Equal(t, Solution{"a": 0, "b": 1, "c": 1, "d": 0}, envelope.Solution())
+	assert.Same(t, prepared.forTouchedComponents(touched), prepared.forTouchedComponents(touched))
+}
+
+func Test_SolutionCreator_Create_givenUntouchedComponent_shouldEqualSolutionWithoutComponents(
+	t *testing.T,
+) {
+	// a XOR b and c XOR d, without any rule between them
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("a", "b", "c", "d")
+	abXor, _ := creator.SetXor("a", "b")
+	cdXor, _ := creator.SetXor("c", "d")
+	_ = creator.Assume(abXor, cdXor)
+	ruleset, _ := creator.Create()
+
+	monolith := ruleset
+	monolith.components = nil
+	selections := Selections{
+		NewSelectionBuilder("d").Build(),
+		NewSelectionBuilder("c").WithAction(REMOVE).Build(),
+	}
+
+	for _, selections := range []Selections{nil, selections} {
+		solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+		want, err := solutionCreator.Create(
+			NewSolutionQueryBuilder().WithRuleset(monolith).WithSelections(selections).Build(),
+		)
+		require.NoError(t, err)
+
+		got, err := solutionCreator.Create(
+			NewSolutionQueryBuilder().WithRuleset(ruleset).WithSelections(selections).Build(),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, want.Solution(), got.Solution())
+	}
+}
+
+func Test_SolutionCreator_Create_givenHardRemovalOfFixedVariable_shouldFail(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("a", "b", "c")
+	_ = creator.Assume("a")
+	abXor, _ := creator.SetXor("b", "c")
+	_ = creator.Assume(abXor)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	_, err = NewSolutionCreator(bruteForceSolverClient{}).Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("a").WithAction(REMOVE).WithHard(true).Build(),
+			}).
+			Build(),
+	)
+
+	assert.ErrorIs(t, err, puanerror.SolverFailed)
+}
+
+func Test_SolutionCreator_Create_givenFromAfterOnlyPeriod_shouldFail(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AddPrimitives("x", "y")
+	xOrY, _ := creator.SetOr("x", "y")
+	_ = creator.Assume(xOrY)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+	afterEnd := newTestTime("2024-02-15T00:00:00Z")
+
+	_, err = NewSolutionCreator(bruteForceSolverClient{}).Create(
+		NewSolutionQueryBuilder().WithRuleset(ruleset).WithFrom(&afterEnd).Build(),
+	)
+
+	assert.Error(t, err)
+}
@@ -21,6 +21,16 @@ type RulesetCreator struct {
 	forbiddenPeriods            []Period
 	timeBoundAssumedVariables   TimeBoundVariables
 	timeBoundPreferredVariables TimeBoundVariables
+	timeBoundPrimitives         TimeBoundVariables
+	timeNormalizer              *timeNormalizer
+
+	// Periods added by recurrences, limited by maxRecurrencePeriods,
+	// or by DefaultMaxTotalRecurrencePeriods if not set
+	recurrencePeriods    int
+	maxRecurrencePeriods int
+
+	// Assumes each time-bound assumed variable on a row of its own
+	periodFeasibilityAnalysis bool
 }
 
 func NewRulesetCreator() *RulesetCreator {
@@ -44,6 +54,37 @@ func (c *RulesetCreator) AddPrimitives(primitives ...string) error {
 	return c.model.AddPrimitives(primitives...)
 }
 
+// Adds primitives that only exist during the period,
+// e.g. options introduced or discontinued mid-year.
+// Outside of the period the primitives cannot be selected.
+func (c *RulesetCreator) AddPrimitivesInPeriod(
+	from, to time.Time,
+	primitives ...string,
+) error {
+	variables := make(TimeBoundVariables, len(primitives))
+	for i, primitive := range primitives {
+		variable, err := c.newTimeBoundVariable(primitive, from, to)
+		if err != nil {
+			return err
+		}
+		variables[i] = variable
+	}
+
+	if err := c.AddPrimitives(primitives...); err != nil {
+		return err
+	}
+
+	// Primitives existing during the entire ruleset period
+	// need no time bounding.
+	for _, variable := range variables {
+		if !c.period.isEqual(variable.period) {
+			c.timeBoundPrimitives = append(c.timeBoundPrimitives, variable)
+		}
+	}
+
+	return nil
+}
+
 func (c *RulesetCreator) SetAnd(variables ...string) (string, error) {
 	return c.model.SetAnd(variables...)
 }
@@ -72,6 +113,35 @@ func (c *RulesetCreator) SetEquivalent(variableOne, variableTwo string) (string,
 	return c.model.SetEquivalent(variableOne, variableTwo)
 }
 
+// Sets a rule that is only active during the period,
+// e.g. "from 2026-09 sunroof requires roof rails".
+func (c *RulesetCreator) SetImplyInPeriod(
+	condition, consequence string,
+	from, to time.Time,
+) (string, error) {
+	// Validated before the implication is set, not to
+	// leave it behind if the period is invalid
+	period, err := c.newTimeBoundPeriod(newTimeEdge(from), newTimeEdge(to))
+	if err != nil {
+		return "", err
+	}
+
+	id, err := c.SetImply(condition, consequence)
+	if err != nil {
+		return "", err
+	}
+
+	variable := TimeBoundVariable{
+		variable: id,
+		period:   period,
+	}
+	if err := c.assumeTimeBoundVariable(variable); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
 func (c *RulesetCreator) Prefer(ids ...string) error {
 	negatedIDs, err := c.negatePreferreds(ids)
 	if err != nil {
@@ -106,12 +176,30 @@ func (c *RulesetCreator) PreferInPeriod(
 		return err
 	}
 
+	return c.preferTimeBoundVariable(variable)
+}
+
+// Prefers the variable from the time onwards if to is nil,
+// or until the time if from is nil, see NewOpenPeriod
+func (c *RulesetCreator) PreferInOpenPeriod(
+	id string,
+	from, to *time.Time,
+) error {
+	variable, err := c.newTimeBoundVariableBetween(id, newStartEdge(from), newEndEdge(to))
+	if err != nil {
+		return err
+	}
+
+	return c.preferTimeBoundVariable(variable)
+}
+
+func (c *RulesetCreator) preferTimeBoundVariable(variable TimeBoundVariable) error {
 	// If the variable period is equal to the ruleset period,
 	// i.e., is preferred during the entire ruleset period,
 	// it can be preferred directly without time bounding.
 	preferredDuringRulesetPeriod := c.period.isEqual(variable.period)
 	if preferredDuringRulesetPeriod {
-		return c.Prefer(id)
+		return c.Prefer(variable.variable)
 	}
 
 	c.timeBoundPreferredVariables = append(c.timeBoundPreferredVariables, variable)
@@ -142,6 +230,24 @@ func (c *RulesetCreator) AssumeInPeriod(
 		return err
 	}
 
+	return c.assumeTimeBoundVariable(variable)
+}
+
+// Assumes the variable from the time onwards if to is nil,
+// or until the time if from is nil, see NewOpenPeriod
+func (c *RulesetCreator) AssumeInOpenPeriod(
+	id string,
+	from, to *time.Time,
+) error {
+	variable, err := c.newTimeBoundVariableBetween(id, newStartEdge(from), newEndEdge(to))
+	if err != nil {
+		return err
+	}
+
+	return c.assumeTimeBoundVariable(variable)
+}
+
+func (c *RulesetCreator) assumeTimeBoundVariable(variable TimeBoundVariable) error {
 	// If the variable period is equal to the ruleset period,
 	// i.e., is available during the entire ruleset period,
 	// it can be assumed directly without time bounding.
@@ -158,20 +264,40 @@ func (c *RulesetCreator) newTimeBoundVariable(
 	id string,
 	from, to time.Time,
 ) (TimeBoundVariable, error) {
+	return c.newTimeBoundVariableBetween(id, newTimeEdge(from), newTimeEdge(to))
+}
+
+func (c *RulesetCreator) newTimeBoundVariableBetween(
+	id string,
+	start, end periodEdge,
+) (TimeBoundVariable, error) {
+	period, err := c.newTimeBoundPeriod(start, end)
+	if err != nil {
+		return TimeBoundVariable{}, err
+	}
+
+	return TimeBoundVariable{
+		variable: id,
+		period:   period,
+	}, nil
+}
+
+// Period within the enabled period
+func (c *RulesetCreator) newTimeBoundPeriod(start, end periodEdge) (Period, error) {
 	if c.timeDisabled() {
-		return TimeBoundVariable{}, errors.Errorf(
+		return Period{}, errors.Errorf(
 			"%w: time support not enabled. Call EnableTime() first",
 			puanerror.InvalidOperation,
 		)
 	}
 
-	period, err := NewPeriod(from, to)
+	period, err := c.newPeriod(start, end)
 	if err != nil {
-		return TimeBoundVariable{}, err
+		return Period{}, err
 	}
 
 	if !c.period.contains(period) {
-		return TimeBoundVariable{},
+		return Period{},
 			errors.Errorf(
 				"%w: period %v is outside of enabled period %v",
 				puanerror.InvalidArgument,
@@ -180,16 +306,57 @@ func (c *RulesetCreator) newTimeBoundVariable(
 			)
 	}
 
-	return TimeBoundVariable{
-		variable: id,
-		period:   period,
-	}, nil
+	return period, nil
+}
+
+// Snaps all period edges to the granularity in the location,
+// so that rulesets with times from several sources partition predictably.
+// Must be called before EnableTime.
+func (c *RulesetCreator) NormalizeTime(
+	location *time.Location,
+	granularity Granularity,
+) error {
+	if !c.timeDisabled() {
+		return errors.Errorf(
+			"%w: time normalization must be set before EnableTime()",
+			puanerror.InvalidOperation,
+		)
+	}
+
+	normalizer, err := newTimeNormalizer(location, granularity)
+	if err != nil {
+		return err
+	}
+
+	c.timeNormalizer = &normalizer
+
+	return nil
+}
+
+func (c *RulesetCreator) newPeriod(start, end periodEdge) (Period, error) {
+	if c.timeNormalizer == nil {
+		return newPeriod(start, end)
+	}
+
+	return c.timeNormalizer.newPeriod(start, end)
 }
 
 func (c *RulesetCreator) EnableTime(
 	from, to time.Time,
 ) error {
-	period, err := NewPeriod(from, to)
+	return c.enableTime(newTimeEdge(from), newTimeEdge(to))
+}
+
+// Enables time with an open-ended horizon, from the time onwards
+// if to is nil or until the time if from is nil, see NewOpenPeriod
+func (c *RulesetCreator) EnableOpenTime(
+	from, to *time.Time,
+) error {
+	return c.enableTime(newStartEdge(from), newEndEdge(to))
+}
+
+func (c *RulesetCreator) enableTime(start, end periodEdge) error {
+	period, err := c.newPeriod(start, end)
 	if err != nil {
 		return err
 	}
@@ -209,7 +376,7 @@ func (c *RulesetCreator) ForbidPeriod(
 		)
 	}
 
-	period, err := NewPeriod(from, to)
+	period, err := c.newPeriod(newTimeEdge(from), newTimeEdge(to))
 	if err != nil {
 		return err
 	}
@@ -223,6 +390,165 @@ func (c *RulesetCreator) ForbidPeriod(
 	return nil
 }
 
+// Assumes the variable in every period of the recurrence.
+// Nothing is assumed if any of the periods is invalid.
+func (c *RulesetCreator) AssumeInRecurrence(id string, recurrence Recurrence) error {
+	variables, err := c.newRecurrenceVariables(id, recurrence)
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range variables {
+		if err := c.assumeTimeBoundVariable(variable); err != nil {
+			return err
+		}
+	}
+
+	c.recurrencePeriods += len(variables)
+
+	return nil
+}
+
+// Prefers the variable in every period of the recurrence.
+// Nothing is preferred if any of the periods is invalid.
+func (c *RulesetCreator) PreferInRecurrence(id string, recurrence Recurrence) error {
+	variables, err := c.newRecurrenceVariables(id, recurrence)
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range variables {
+		if err := c.preferTimeBoundVariable(variable); err != nil {
+			return err
+		}
+	}
+
+	c.recurrencePeriods += len(variables)
+
+	return nil
+}
+
+// Forbids every period of the recurrence.
+// Nothing is forbidden if any of the periods is invalid.
+func (c *RulesetCreator) ForbidRecurrence(recurrence Recurrence) error {
+	periods, err := c.expandRecurrence(recurrence)
+	if err != nil {
+		return err
+	}
+
+	forbidden := make([]Period, len(periods))
+	for i, period := range periods {
+		forbidden[i], err = c.newPeriod(period.start(), period.end())
+		if err != nil {
+			return err
+		}
+
+		if err := c.validateForbiddenPeriod(forbidden[i]); err != nil {
+			return err
+		}
+
+		// Normalization may join periods of the recurrence
+		if i > 0 && forbidden[i-1].overlaps(forbidden[i]) {
+			return errors.Errorf(
+				"%w: periods %v and %v of the recurrence overlap",
+				puanerror.InvalidArgument,
+				forbidden[i-1],
+				forbidden[i],
+			)
+		}
+	}
+
+	c.forbiddenPeriods = append(c.forbiddenPeriods, forbidden...)
+	c.recurrencePeriods += len(forbidden)
+
+	return nil
+}
+
+// Limits the total number of periods that recurrences of the creator may
+// expand into, since each period partitions the ruleset period further.
+// Defaults to DefaultMaxTotalRecurrencePeriods.
+func (c *RulesetCreator) LimitRecurrencePeriods(maxPeriods int) error {
+	if maxPeriods < 1 {
+		return errors.Errorf(
+			"%w: max periods must be positive, got %d",
+			puanerror.InvalidArgument,
+			maxPeriods,
+		)
+	}
+
+	c.maxRecurrencePeriods = maxPeriods
+
+	return nil
+}
+
+// Assumes each time-bound assumed variable on a constraint and row of its
+// own, so that SolutionCreator.AnalyzePeriodFeasibility can relax them one
+// by one and report the conflicting ones. Without it, the time-bound
+// assumptions of the same periods share one constraint.
+func (c *RulesetCreator) EnablePeriodFeasibilityAnalysis() {
+	c.periodFeasibilityAnalysis = true
+}
+
+// Time-bound variables of every period of the recurrence,
+// validated before any of them is added to the creator
+func (c *RulesetCreator) newRecurrenceVariables(
+	id string,
+	recurrence Recurrence,
+) (TimeBoundVariables, error) {
+	periods, err := c.expandRecurrence(recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.model.ValidateVariables(id)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := make(TimeBoundVariables, len(periods))
+	for i, period := range periods {
+		variables[i], err = c.newTimeBoundVariableBetween(id, period.start(), period.end())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return variables, nil
+}
+
+func (c *RulesetCreator) expandRecurrence(recurrence Recurrence) ([]Period, error) {
+	if c.timeDisabled() {
+		return nil, errors.Errorf(
+			"%w: time support not enabled. Call EnableTime() first",
+			puanerror.InvalidOperation,
+		)
+	}
+
+	if err := recurrence.validate(); err != nil {
+		return nil, err
+	}
+
+	periods, err := recurrence.Expand(*c.period)
+	if err != nil {
+		return nil, err
+	}
+
+	maxPeriods := c.maxRecurrencePeriods
+	if maxPeriods == 0 {
+		maxPeriods = DefaultMaxTotalRecurrencePeriods
+	}
+
+	if c.recurrencePeriods+len(periods) > maxPeriods {
+		return nil, errors.Errorf(
+			"%w: recurrences expand into more than %d periods in total",
+			puanerror.InvalidArgument,
+			maxPeriods,
+		)
+	}
+
+	return periods, nil
+}
+
 func (c *RulesetCreator) validateForbiddenPeriod(period Period) error {
 	if !c.period.contains(period) {
 		return errors.Errorf(
@@ -262,7 +588,7 @@ func (c *RulesetCreator) Create() (Ruleset, error) {
 		return Ruleset{}, err
 	}
 
-	err = c.createPeriodConstraints(periodVariables)
+	timeBoundAssumedVariables, err := c.createPeriodConstraints(periodVariables)
 	if err != nil {
 		return Ruleset{}, err
 	}
@@ -306,6 +632,7 @@ func (c *RulesetCreator) Create() (Ruleset, error) {
 		independentVariables,
 		preferredVariables,
 		periodVariables,
+		timeBoundAssumedVariables,
 	)
 }
 
@@ -359,7 +686,7 @@ func (c *RulesetCreator) newPeriodVariables(
 	for i := range len(orderedPeriods) - 1 {
 		previous := orderedPeriods[i]
 		current := orderedPeriods[i+1]
-		invalidOrder := current.from.Before(previous.to)
+		invalidOrder := current.start().compare(previous.end()) < 0
 		if invalidOrder {
 			return nil, errors.Errorf(
 				"period %v must be after %v",
@@ -387,35 +714,45 @@ func (c *RulesetCreator) periods() []Period {
 	periods = append(periods, c.forbiddenPeriods...)
 	periods = append(periods, c.timeBoundAssumedVariables.periods()...)
 	periods = append(periods, c.timeBoundPreferredVariables.periods()...)
+	periods = append(periods, c.timeBoundPrimitives.periods()...)
 	return periods
 }
 
+// Returns the time-bound assumed variables in the periods
 func (c *RulesetCreator) createPeriodConstraints(
 	periodVariables TimeBoundVariables,
-) error {
+) (TimeBoundVariables, error) {
 	if c.timeDisabled() {
-		return nil
+		return nil, nil
 	}
 
-	if err := c.createTimeBoundAssumeConstraints(periodVariables); err != nil {
-		return err
+	assumedVariables, err := c.createTimeBoundAssumeConstraints(periodVariables)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.createTimeBoundPrimitiveConstraints(periodVariables); err != nil {
+		return nil, err
 	}
 
 	if err := c.createExactlyOnePeriodConstraint(periodVariables); err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return assumedVariables, nil
 }
 
 func (c *RulesetCreator) createTimeBoundAssumeConstraints(
 	periodVariables TimeBoundVariables,
-) error {
+) (TimeBoundVariables, error) {
 	assumedVariables := c.getTimeBoundAssumedVariablesInPeriods(periodVariables.periods())
+	if c.periodFeasibilityAnalysis {
+		return c.createRelaxableTimeBoundAssumeConstraints(periodVariables, assumedVariables)
+	}
 
 	groupedByPeriods, err := groupByPeriods(periodVariables, assumedVariables)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var constraintIDs []string
@@ -423,12 +760,94 @@ func (c *RulesetCreator) createTimeBoundAssumeConstraints(
 		periodIDs := serializedPeriodIDs.ids()
 		constraintID, err := c.setTimeBoundConstraint(periodIDs, assumedIDs)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		constraintIDs = append(constraintIDs, constraintID)
 	}
 
-	return c.Assume(constraintIDs...)
+	return assumedVariables, c.Assume(constraintIDs...)
+}
+
+// Each time-bound assumed variable gets a constraint assumed on a row of
+// its own, so that it can be relaxed when analysing period feasibility.
+// Returns the variables with the IDs of their constraints.
+func (c *RulesetCreator) createRelaxableTimeBoundAssumeConstraints(
+	periodVariables TimeBoundVariables,
+	assumedVariables TimeBoundVariables,
+) (TimeBoundVariables, error) {
+	relaxable := make(TimeBoundVariables, len(assumedVariables))
+	for i, variable := range assumedVariables {
+		periodIDs, err := findContainingPeriodIDs(periodVariables, variable.period)
+		if err != nil {
+			return nil, err
+		}
+
+		constraintID, err := c.setTimeBoundConstraint(
+			periodIDs.ids(),
+			[]string{variable.variable},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		relaxable[i] = NewTimeBoundAssumedVariable(
+			variable.variable,
+			variable.period,
+			constraintID,
+		)
+	}
+
+	constraintIDs := make([]string, len(relaxable))
+	for i, variable := range relaxable {
+		constraintIDs[i] = variable.constraintID
+	}
+
+	for _, constraintID := range utils.Sorted(utils.Dedupe(constraintIDs)) {
+		if err := c.model.Assume(constraintID); err != nil {
+			return nil, err
+		}
+	}
+
+	return relaxable, nil
+}
+
+// Each time-bound primitive implies any of the periods it exists in.
+// Primitives only existing in forbidden periods can never be selected.
+func (c *RulesetCreator) createTimeBoundPrimitiveConstraints(
+	periodVariables TimeBoundVariables,
+) error {
+	for _, primitive := range c.timeBoundPrimitives {
+		periodIDs := periodVariables.overlapping(primitive.period).ids()
+
+		var constraintID string
+		var err error
+		if len(periodIDs) == 0 {
+			constraintID, err = c.SetNot(primitive.variable)
+		} else {
+			constraintID, err = c.setTimeBoundPrimitiveConstraint(primitive.variable, periodIDs)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := c.Assume(constraintID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *RulesetCreator) setTimeBoundPrimitiveConstraint(
+	primitive string,
+	periodIDs []string,
+) (string, error) {
+	anyPeriodID, err := c.setSingleOrOR(periodIDs...)
+	if err != nil {
+		return "", err
+	}
+
+	return c.SetImply(primitive, anyPeriodID)
 }
 
 func (c *RulesetCreator) getTimeBoundAssumedVariablesInPeriods(
@@ -559,3 +559,170 @@ func Test_RulesetCreator_Create_givenPreferredInForbiddenPeriod_shouldNotBeInclu
 	)
 	assert.Empty(t, ruleset.PreferredVariables())
 }
+
+func Test_RulesetCreator_givenOpenEndedHorizon_shouldAssumeFromTimeOnwards(t *testing.T) {
+	start := newTestTime("2024-01-01T00:00:00Z")
+	release := newTestTime("2024-06-01T00:00:00Z")
+
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x")
+	require.NoError(t, creator.EnableOpenTime(&start, nil))
+	require.NoError(t, creator.AssumeInOpenPeriod("x", &release, nil))
+
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	from := newTestTime("2030-01-01T00:00:00Z")
+	envelope, err := NewSolutionCreator(bruteForceSolverClient{}).Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithFrom(&from).
+			Build(),
+	)
+	require.NoError(t, err)
+
+	period, err := ruleset.FindPeriodInSolution(envelope.Solution())
+	require.NoError(t, err)
+	assert.Equal(t, release, period.From())
+	assert.True(t, period.IsOpenEnd())
+	assert.Equal(t, 1, envelope.Solution()["x"])
+}
+
+func Test_RulesetCreator_AddPrimitivesInPeriod_givenTimeDisabled_shouldReturnError(
+	t *testing.T,
+) {
+	creator := NewRulesetCreator()
+
+	err := creator.AddPrimitivesInPeriod(
+		newTestTime("2024-01-10T00:00:00Z"),
+		newTestTime("2024-01-20T00:00:00Z"),
+		"x",
+	)
+
+	assert.ErrorIs(t, err, puanerror.InvalidOperation)
+}
+
+func Test_RulesetCreator_AddPrimitivesInPeriod_givenReservedPrefix_shouldReturnError(
+	t *testing.T,
+) {
+	creator := NewRulesetCreator()
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+
+	err := creator.AddPrimitivesInPeriod(
+		newTestTime("2024-01-10T00:00:00Z"),
+		newTestTime("2024-01-20T00:00:00Z"),
+		"period_x",
+	)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_RulesetCreator_AddPrimitivesInPeriod_shouldOnlyBeSelectableInPeriod(
+	t *testing.T,
+) {
+	creator := NewRulesetCreator()
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	err := creator.AddPrimitivesInPeriod(
+		newTestTime("2024-01-10T00:00:00Z"),
+		newTestTime("2024-01-20T00:00:00Z"),
+		"x",
+	)
+	require.NoError(t, err)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	envelope, err := NewSolutionCreator(bruteForceSolverClient{}).CreateTimeline(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("x").Build()}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]TimelineEntry{
+			{
+				period:   newTestPeriod("2024-01-01T00:00:00Z", "2024-01-10T00:00:00Z"),
+				solution: Solution{"x": 0},
+			},
+			{
+				period:   newTestPeriod("2024-01-10T00:00:00Z", "2024-01-20T00:00:00Z"),
+				solution: Solution{"x": 1},
+			},
+			{
+				period:   newTestPeriod("2024-01-20T00:00:00Z", "2024-01-31T00:00:00Z"),
+				solution: Solution{"x": 0},
+			},
+		},
+		envelope.Entries(),
+	)
+}
+
+func Test_RulesetCreator_SetImplyInPeriod_givenPeriodOutsideEnabled_shouldNotSetRule(
+	t *testing.T,
+) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("sunroof", "roofRails")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+
+	_, err := creator.SetImplyInPeriod(
+		"sunroof",
+		"roofRails",
+		newTestTime("2024-01-15T00:00:00Z"),
+		newTestTime("2024-02-15T00:00:00Z"),
+	)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+	assert.ElementsMatch(t, []string{"sunroof", "roofRails"}, creator.model.Variables())
+}
+
+func Test_RulesetCreator_SetImplyInPeriod_shouldOnlyApplyRuleInPeriod(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("sunroof", "roofRails")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_, err := creator.SetImplyInPeriod(
+		"sunroof",
+		"roofRails",
+		newTestTime("2024-01-15T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	require.NoError(t, err)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	envelope, err := NewSolutionCreator(bruteForceSolverClient{}).CreateTimeline(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("sunroof").Build()}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]TimelineEntry{
+			{
+				period:   newTestPeriod("2024-01-01T00:00:00Z", "2024-01-15T00:00:00Z"),
+				solution: Solution{"sunroof": 1, "roofRails": 0},
+			},
+			{
+				period:   newTestPeriod("2024-01-15T00:00:00Z", "2024-01-31T00:00:00Z"),
+				solution: Solution{"sunroof": 1, "roofRails": 1},
+			},
+		},
+		envelope.Entries(),
+	)
+}
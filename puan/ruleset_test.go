@@ -22,6 +22,7 @@ func Test_RuleSet_copy_shouldBeEqual(t *testing.T) {
 	selectableVariables := fake.New[[]string]()
 	preferredVariables := fake.New[[]string]()
 	periodVariables := fake.New[[]TimeBoundVariable]()
+	timeBoundAssumedVariables := fake.New[[]TimeBoundVariable]()
 
 	original := Ruleset{}
 	original.polyhedron = polyhedron
@@ -30,6 +31,7 @@ func Test_RuleSet_copy_shouldBeEqual(t *testing.T) {
 	original.independentVariables = independentVariables
 	original.preferredVariables = preferredVariables
 	original.periodVariables = periodVariables
+	original.timeBoundAssumedVariables = timeBoundAssumedVariables
 	ccopy := original.copy()
 
 	assert.True(t, reflect.DeepEqual(original, ccopy))
@@ -118,7 +120,7 @@ func Test_RuleSet_getWeightSelectionID_givenCompositeSelection_andRulesetPrepare
 	ruleset, err := creator.Create()
 	require.NoError(t, err)
 
-	err = ruleset.setCompositeSelectionConstraint(selection.IDs())
+	err = ruleset.setCompositeSelection(selection)
 	require.NoError(t, err)
 
 	got, err := ruleset.getWeightSelectionID(selection)
@@ -129,7 +131,7 @@ func Test_RuleSet_getWeightSelectionID_givenCompositeSelection_andRulesetPrepare
 }
 
 // nolint:lll
-func Test_RuleSet_setCompositeSelectionConstraint_givenConstraintDoesNotExist_shouldSetNewConstraint(
+func Test_RuleSet_setCompositeSelection_givenConstraintDoesNotExist_shouldSetNewConstraint(
 	t *testing.T,
 ) {
 	primaryID := uuid.New().String()
@@ -146,7 +148,7 @@ func Test_RuleSet_setCompositeSelectionConstraint_givenConstraintDoesNotExist_sh
 
 	selection := NewSelectionBuilder(primaryID).WithSubSelectionID(subID).Build()
 
-	err := ruleset.setCompositeSelectionConstraint(selection.IDs())
+	err := ruleset.setCompositeSelection(selection)
 
 	assert.NoError(t, err)
 	assert.Len(t, ruleset.dependentVariables, 5)
@@ -155,7 +157,7 @@ func Test_RuleSet_setCompositeSelectionConstraint_givenConstraintDoesNotExist_sh
 	assert.Len(t, ruleset.polyhedron.A()[0], 5)
 }
 
-func Test_RuleSet_setCompositeSelectionConstraint_givenConstraintExists_shouldNotSetNewConstraint(
+func Test_RuleSet_setCompositeSelection_givenConstraintExists_shouldNotSetNewConstraint(
 	t *testing.T,
 ) {
 	primaryID := uuid.New().String()
@@ -171,7 +173,7 @@ func Test_RuleSet_setCompositeSelectionConstraint_givenConstraintExists_shouldNo
 
 	selection := NewSelectionBuilder(primaryID).WithSubSelectionID(subID).Build()
 
-	err := ruleset.setCompositeSelectionConstraint(selection.IDs())
+	err := ruleset.setCompositeSelection(selection)
 
 	assert.NoError(t, err)
 	assert.Equal(t, wantVariables, ruleset.dependentVariables)
@@ -219,6 +221,49 @@ func Test_newCompositeSelectionConstraint_shouldCreateConstraintWithoutDuplicate
 	assert.Equal(t, want, got)
 }
 
+func Test_newCompositeSelectionConstraints_givenAllOf_shouldOnlyCreateAllOfConstraint(
+	t *testing.T,
+) {
+	selection := NewSelectionBuilder("x").WithSubSelectionID("a").Build()
+
+	got, err := newCompositeSelectionConstraints(selection)
+
+	want, _ := newCompositeSelectionConstraint([]string{"x", "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, pldag.Constraints{want}, got)
+}
+
+func Test_newCompositeSelectionConstraints_givenAnyOf_shouldCombineSubSelectionsWithOr(
+	t *testing.T,
+) {
+	selection := NewSelectionBuilder("x").
+		WithSubSelectionID("a").
+		WithSubSelectionID("b").
+		WithCompositeKind(ANY_OF).
+		Build()
+
+	got, err := newCompositeSelectionConstraints(selection)
+
+	anyOf, _ := pldag.NewAtLeastConstraint([]string{"a", "b"}, 1)
+	allOf, _ := newCompositeSelectionConstraint([]string{"x", anyOf.ID()})
+	assert.NoError(t, err)
+	assert.Equal(t, pldag.Constraints{anyOf, allOf}, got)
+}
+
+func Test_newCompositeSelectionConstraints_givenNested_shouldCreateNestedConstraintFirst(
+	t *testing.T,
+) {
+	nested := NewSelectionBuilder("a").WithSubSelectionID("b").Build()
+	selection := NewSelectionBuilder("x").WithSubSelection(nested).Build()
+
+	got, err := newCompositeSelectionConstraints(selection)
+
+	nestedAllOf, _ := newCompositeSelectionConstraint([]string{"a", "b"})
+	allOf, _ := newCompositeSelectionConstraint([]string{"x", nestedAllOf.ID()})
+	assert.NoError(t, err)
+	assert.Equal(t, pldag.Constraints{nestedAllOf, allOf}, got)
+}
+
 func Test_RuleSet_newRow(
 	t *testing.T,
 ) {
@@ -242,7 +287,7 @@ func Test_RuleSet_newRow(
 	got, err := ruleset.newRow(coefficients)
 
 	assert.NoError(t, err)
-	assert.Equal(t, []int{0, value1, value2, 0}, got)
+	assert.Equal(t, pldag.NewSparseRow([]int{1, 2}, []int{value1, value2}), got)
 }
 
 func Test_RuleSet_setConstraint_shouldAddColumnOnExistingRows(t *testing.T) {
@@ -332,6 +377,26 @@ func Test_RuleSet_FindPeriodInSolution_givenSingleMatchingPeriod_shouldReturnPer
 	assert.Equal(t, period1, result)
 }
 
+func Test_RuleSet_FindPeriodInSolution_givenOpenEndedPeriod_shouldReturnOpenEndedPeriod(
+	t *testing.T,
+) {
+	from := newTestTime("2024-01-01T00:00:00Z")
+	onwards, err := NewOpenPeriod(&from, nil)
+	require.NoError(t, err)
+
+	ruleset := &Ruleset{
+		periodVariables: TimeBoundVariables{
+			{variable: "onwards", period: onwards},
+		},
+	}
+
+	result, err := ruleset.FindPeriodInSolution(Solution{"onwards": 1})
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsOpenEnd())
+	assert.True(t, result.To().IsZero())
+}
+
 func Test_RuleSet_FindPeriodInSolution_givenNoMatchingPeriod_shouldReturnError(
 	t *testing.T,
 ) {
@@ -505,3 +570,64 @@ func Test_RuleSet_isValidFromTime(t *testing.T) {
 		})
 	}
 }
+
+func Test_HydrateSparseRuleSet_givenSerializedRuleset_shouldEqualOriginal(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "z")
+	or, _ := creator.SetOr("x", "y")
+	_ = creator.Assume(or)
+	original, err := creator.Create()
+	require.NoError(t, err)
+
+	hydrated, err := HydrateSparseRuleSet(
+		original.Polyhedron().SparseMatrix(),
+		original.Polyhedron().B(),
+		original.DependentVariables(),
+		original.IndependentVariables(),
+		original.SelectableVariables(),
+		original.PreferredVariables(),
+		original.PeriodVariables(),
+		WithTimeBoundAssumedVariables(original.TimeBoundAssumedVariables()),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, original.Polyhedron().A(), hydrated.Polyhedron().A())
+	assert.Equal(t, original.Polyhedron().B(), hydrated.Polyhedron().B())
+}
+
+func Test_HydrateSparseRuleSet_givenMoreColumnsThanVariables_shouldReturnError(t *testing.T) {
+	_, err := HydrateSparseRuleSet(
+		NewSparseMatrix([]int{0}, []int{1}, []int{1}, 1, 2),
+		[]int{1},
+		[]string{"x"},
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	assert.Error(t, err)
+}
+
+func Test_HydrateRuleSet_givenDenseRuleset_shouldEqualOriginal(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "z")
+	or, _ := creator.SetOr("x", "y")
+	_ = creator.Assume(or)
+	original, err := creator.Create()
+	require.NoError(t, err)
+
+	hydrated, err := HydrateRuleSet(
+		original.Polyhedron().A(),
+		original.Polyhedron().B(),
+		original.DependentVariables(),
+		original.IndependentVariables(),
+		original.SelectableVariables(),
+		original.PreferredVariables(),
+		original.PeriodVariables(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, original.Polyhedron().Rows(), hydrated.Polyhedron().Rows())
+	assert.Equal(t, original.Polyhedron().B(), hydrated.Polyhedron().B())
+}
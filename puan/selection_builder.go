@@ -1,9 +1,14 @@
 package puan
 
 type SelectionBuilder struct {
-	id              string
-	subSelectionIDs []string
-	action          Action
+	id                string
+	subSelectionIDs   []string
+	action            Action
+	exclusiveGroupIDs []string
+	hard              bool
+	compositeKind     CompositeKind
+	siblingIDs        []string
+	nestedSelections  Selections
 }
 
 func NewSelectionBuilder(id string) *SelectionBuilder {
@@ -11,6 +16,7 @@ func NewSelectionBuilder(id string) *SelectionBuilder {
 		id:              id,
 		action:          ADD,
 		subSelectionIDs: []string{},
+		compositeKind:   ALL_OF,
 	}
 }
 
@@ -19,11 +25,50 @@ func (b *SelectionBuilder) WithSubSelectionID(subSelectionID string) *SelectionB
 	return b
 }
 
+// Composite sub-selection, itself combining variables,
+// e.g. a package where one of its options has sub-options
+func (b *SelectionBuilder) WithSubSelection(subSelection Selection) *SelectionBuilder {
+	b.nestedSelections = append(b.nestedSelections, subSelection)
+	return b
+}
+
+// How the sub-selections are combined, defaults to ALL_OF
+func (b *SelectionBuilder) WithCompositeKind(kind CompositeKind) *SelectionBuilder {
+	b.compositeKind = kind
+	return b
+}
+
+// Variables not allowed by an EXACTLY composite
+func (b *SelectionBuilder) WithSiblings(ids ...string) *SelectionBuilder {
+	b.siblingIDs = append(b.siblingIDs, ids...)
+	return b
+}
+
 func (b *SelectionBuilder) WithAction(action Action) *SelectionBuilder {
 	b.action = action
 	return b
 }
 
+// Variables deselected by a SET_EXCLUSIVE selection,
+// may include the selected variable itself
+func (b *SelectionBuilder) WithExclusiveGroup(ids ...string) *SelectionBuilder {
+	b.exclusiveGroupIDs = append(b.exclusiveGroupIDs, ids...)
+	return b
+}
+
+// Hard selections are assumed by the ruleset instead of weighted.
+// A query fails with puanerror.SelectionConflict if they cannot be met.
+func (b *SelectionBuilder) WithHard(hard bool) *SelectionBuilder {
+	b.hard = hard
+	return b
+}
+
 func (b *SelectionBuilder) Build() Selection {
-	return newSelection(b.action, b.id, b.subSelectionIDs)
+	selection := newSelection(b.action, b.id, b.subSelectionIDs)
+	selection.exclusiveGroupIDs = b.exclusiveGroupIDs
+	selection.hard = b.hard
+	selection.compositeKind = b.compositeKind
+	selection.siblingIDs = b.siblingIDs
+	selection.nestedSelections = b.nestedSelections
+	return selection
 }
@@ -0,0 +1,193 @@
+package puan
+
+import (
+	"github.com/ourstudio-se/puan-sdk-go/internal/pldag"
+	"github.com/ourstudio-se/puan-sdk-go/internal/utils"
+)
+
+// Variables connected through the constraints of the ruleset,
+// or through being part of the same selection. Variables fixed by
+// the assumptions of the ruleset connect nothing, since they take
+// the same value whatever is selected.
+func (r *Ruleset) newVariableComponents(selections Selections) *utils.DisjointSet[string] {
+	fixed, _ := findFixedColumns(r.polyhedron)
+
+	components := utils.NewDisjointSet[string]()
+	for i, row := range r.polyhedron.Rows() {
+		reduced, bias := reduceRow(row, r.polyhedron.B()[i], fixed)
+		if isRedundantRow(reduced.Values(), bias) {
+			continue
+		}
+
+		variables := make([]string, len(reduced.Columns()))
+		for j, column := range reduced.Columns() {
+			variables[j] = r.dependentVariables[column]
+		}
+
+		components.Union(variables...)
+	}
+
+	for _, selection := range selections {
+		components.Union(selection.connectedIDs()...)
+	}
+
+	return components
+}
+
+// Columns forced to a value by the rows of the polyhedron, e.g. the
+// assumed root and the constraints it requires. A row A x <= b forces
+// its variables when the smallest possible left side equals b.
+// Returns false if some row cannot be met with the forced values.
+func findFixedColumns(polyhedron *pldag.Polyhedron) (map[int]int, bool) {
+	fixed := make(map[int]int)
+	rows := polyhedron.Rows()
+	for changed := true; changed; {
+		changed = false
+		for i, row := range rows {
+			minimum, bias := rowMinimum(row, polyhedron.B()[i], fixed)
+			if minimum != bias {
+				continue
+			}
+
+			for j, column := range row.Columns() {
+				if _, isFixed := fixed[column]; isFixed {
+					continue
+				}
+
+				fixed[column] = 0
+				if row.Values()[j] < 0 {
+					fixed[column] = 1
+				}
+				changed = true
+			}
+		}
+	}
+
+	for i, row := range rows {
+		minimum, bias := rowMinimum(row, polyhedron.B()[i], fixed)
+		if minimum > bias {
+			return fixed, false
+		}
+	}
+
+	return fixed, true
+}
+
+// Smallest possible left side of the row over the columns not fixed,
+// and the bias with the fixed columns moved to the right side
+func rowMinimum(row pldag.SparseRow, bias int, fixed map[int]int) (int, int) {
+	minimum := 0
+	for j, column := range row.Columns() {
+		if value, isFixed := fixed[column]; isFixed {
+			bias -= row.Values()[j] * value
+		} else {
+			minimum += min(0, row.Values()[j])
+		}
+	}
+
+	return minimum, bias
+}
+
+// The row without the fixed columns, which are moved to the bias
+func reduceRow(row pldag.SparseRow, bias int, fixed map[int]int) (pldag.SparseRow, int) {
+	var columns []int
+	var values []int
+	for j, column := range row.Columns() {
+		if value, isFixed := fixed[column]; isFixed {
+			bias -= row.Values()[j] * value
+			continue
+		}
+
+		columns = append(columns, column)
+		values = append(values, row.Values()[j])
+	}
+
+	return pldag.NewSparseRow(columns, values), bias
+}
+
+// A row met by any values of its binary variables
+func isRedundantRow(coefficients []int, bias int) bool {
+	maximum := 0
+	for _, coefficient := range coefficients {
+		maximum += max(0, coefficient)
+	}
+
+	return maximum <= bias
+}
+
+// Variables of a selection, including those it removes or forbids
+func (s Selection) connectedIDs() []string {
+	ids := s.IDs()
+	ids = append(ids, s.exclusiveGroupIDs...)
+	ids = append(ids, s.siblingIDs...)
+	return ids
+}
+
+// Groups of selections not connected to each other, each group in
+// order of occurrence. Groups can be solved on their own, since no
+// selection of one group impacts the variables of another.
+func partitionSelections(
+	selections Selections,
+	components *utils.DisjointSet[string],
+) []Selections {
+	var roots []string
+	groups := make(map[string]Selections)
+	for _, selection := range selections {
+		root := components.Find(selection.id)
+		if _, exists := groups[root]; !exists {
+			roots = append(roots, root)
+		}
+
+		groups[root] = append(groups[root], selection)
+	}
+
+	partitioned := make([]Selections, len(roots))
+	for i, root := range roots {
+		partitioned[i] = groups[root]
+	}
+
+	return partitioned
+}
+
+// Solves groups of selections that are not connected concurrently.
+// The objective is a sum over variables, so each group is solved
+// to the same values as in a query with all selections. Variables
+// not connected to any selection are taken from the first group.
+func (c *SolutionCreator) calculateDependentSolutionByComponent(
+	query SolutionQuery,
+	groups []Selections,
+	components *utils.DisjointSet[string],
+) (Solution, error) {
+	solutions := make([]Solution, len(groups))
+	errs := make([]error, len(groups))
+	jobs := make([]func(), len(groups))
+	for i, group := range groups {
+		groupQuery := NewSolutionQueryBuilder().
+			fromQuery(query).
+			WithSelections(group).
+			Build()
+		jobs[i] = func() {
+			solutions[i], errs[i] = c.calculateDependentSolution(groupQuery)
+		}
+	}
+
+	c.workers.run(jobs)
+
+	for _, err := range errs {
+		if err != nil {
+			return Solution{}, err
+		}
+	}
+
+	solution := solutions[0].copy()
+	for i, group := range groups {
+		root := components.Find(group[0].id)
+		for variable, value := range solutions[i] {
+			if components.Find(variable) == root {
+				solution[variable] = value
+			}
+		}
+	}
+
+	return solution, nil
+}
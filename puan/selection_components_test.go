@@ -0,0 +1,79 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_partitionSelections_shouldGroupConnectedSelections(t *testing.T) {
+	// a XOR b and c XOR d, without any rule between them
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("a", "b", "c", "d")
+	abXor, _ := creator.SetXor("a", "b")
+	cdXor, _ := creator.SetXor("c", "d")
+	_ = creator.Assume(abXor, cdXor)
+	ruleset, _ := creator.Create()
+
+	a := NewSelectionBuilder("a").Build()
+	b := NewSelectionBuilder("b").Build()
+	c := NewSelectionBuilder("c").Build()
+	selections := Selections{a, c, b}
+
+	components := ruleset.newVariableComponents(selections)
+	actual := partitionSelections(selections, components)
+
+	assert.Equal(t, []Selections{{a, b}, {c}}, actual)
+}
+
+func Test_partitionSelections_givenCompositeAcrossComponents_shouldJoinGroups(t *testing.T) {
+	// a XOR b and c XOR d, without any rule between them
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("a", "b", "c", "d")
+	abXor, _ := creator.SetXor("a", "b")
+	cdXor, _ := creator.SetXor("c", "d")
+	_ = creator.Assume(abXor, cdXor)
+	ruleset, _ := creator.Create()
+
+	composite := NewSelectionBuilder("a").WithSubSelectionID("c").Build()
+	d := NewSelectionBuilder("d").Build()
+	selections := Selections{composite, d}
+
+	components := ruleset.newVariableComponents(selections)
+	actual := partitionSelections(selections, components)
+
+	assert.Equal(t, []Selections{{composite, d}}, actual)
+}
+
+func Test_SolutionCreator_calculateLargeDependentSolution_givenComponents_shouldEqualSingleSolve(
+	t *testing.T,
+) {
+	// a XOR b and c XOR d, without any rule between them
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("a", "b", "c", "d")
+	abXor, _ := creator.SetXor("a", "b")
+	cdXor, _ := creator.SetXor("c", "d")
+	_ = creator.Assume(abXor, cdXor)
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+	query := NewSolutionQueryBuilder().
+		WithRuleset(ruleset).
+		WithSelections(Selections{
+			NewSelectionBuilder("a").Build(),
+			NewSelectionBuilder("c").Build(),
+			NewSelectionBuilder("b").Build(),
+			NewSelectionBuilder("d").WithAction(REMOVE).Build(),
+		}).
+		Build()
+
+	expected, err := solutionCreator.calculateDependentSolution(query)
+	require.NoError(t, err)
+
+	actual, err := solutionCreator.calculateLargeDependentSolution(query)
+
+	require.NoError(t, err)
+	assert.Equal(t, Solution{"a": 0, "b": 1, "c": 1, "d": 0}, actual)
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,237 @@
+package puan
+
+import (
+	"encoding/json"
+
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+// History of selections in a configuration session, in order of occurrence.
+// Undone selections are kept until a new selection is appended, so that
+// they can be redone. Not safe for concurrent use.
+type SelectionLog struct {
+	selections Selections
+	// Undone selections, the latest undone last
+	undone Selections
+}
+
+func NewSelectionLog(selections ...Selection) *SelectionLog {
+	return &SelectionLog{
+		selections: selections,
+	}
+}
+
+// Selections in order of occurrence, to be used in a SolutionQuery
+func (l *SelectionLog) Selections() Selections {
+	selections := make(Selections, len(l.selections))
+	copy(selections, l.selections)
+	return selections
+}
+
+// Appends a selection, discarding any undone selections
+func (l *SelectionLog) Append(selection Selection) {
+	l.selections = append(l.selections, selection)
+	l.undone = nil
+}
+
+func (l *SelectionLog) CanUndo() bool {
+	return len(l.selections) > 0
+}
+
+func (l *SelectionLog) CanRedo() bool {
+	return len(l.undone) > 0
+}
+
+// Removes the latest selection. Returns puanerror.InvalidOperation
+// if there is nothing to undo.
+func (l *SelectionLog) Undo() (Selection, error) {
+	if !l.CanUndo() {
+		return Selection{}, errors.Errorf(
+			"%w: no selection to undo",
+			puanerror.InvalidOperation,
+		)
+	}
+
+	last := len(l.selections) - 1
+	selection := l.selections[last]
+	l.selections = l.selections[:last]
+	l.undone = append(l.undone, selection)
+
+	return selection, nil
+}
+
+// Appends the latest undone selection again. Returns
+// puanerror.InvalidOperation if there is nothing to redo.
+func (l *SelectionLog) Redo() (Selection, error) {
+	if !l.CanRedo() {
+		return Selection{}, errors.Errorf(
+			"%w: no selection to redo",
+			puanerror.InvalidOperation,
+		)
+	}
+
+	last := len(l.undone) - 1
+	selection := l.undone[last]
+	l.undone = l.undone[:last]
+	l.selections = append(l.selections, selection)
+
+	return selection, nil
+}
+
+// Replaces the history with the selections still impacting a query,
+// giving the same solutions. Undone selections are discarded,
+// and undo then steps through the compacted selections.
+func (l *SelectionLog) Compact() {
+	l.selections = l.selections.compact()
+	l.undone = nil
+}
+
+// Selections without those overridden by later selections.
+// Toggles are resolved, since the selections they toggle may be dropped.
+// A toggle without an earlier selection of its variable depends on the
+// solution, so it and the later selections of its variable are all kept.
+func (s Selections) compact() Selections {
+	impacting, _ := s.getImpactingByOrigin()
+
+	var compacted Selections
+	var modified Selections
+	keptIDs := make(map[string]bool)
+	for i, selection := range s {
+		earlier := modified
+		modified = append(modified, selection.modifyForQueryAfter(earlier)...)
+
+		if keptIDs[selection.id] {
+			compacted = append(compacted, selection)
+			continue
+		}
+
+		if selection.action == TOGGLE {
+			resolved, ok := selection.resolveToggle(earlier)
+			if !ok {
+				keptIDs[selection.id] = true
+				compacted = append(compacted, selection)
+				continue
+			}
+			selection = resolved
+		}
+
+		if _, isImpacting := impacting[i]; isImpacting {
+			compacted = append(compacted, selection)
+		}
+	}
+
+	return compacted
+}
+
+type selectionLogJSON struct {
+	Selections []selectionJSON `json:"selections"`
+	Undone     []selectionJSON `json:"undone,omitempty"`
+}
+
+type selectionJSON struct {
+	ID                string          `json:"id"`
+	Action            Action          `json:"action"`
+	SubSelectionIDs   []string        `json:"subSelectionIds,omitempty"`
+	ExclusiveGroupIDs []string        `json:"exclusiveGroupIds,omitempty"`
+	Hard              bool            `json:"hard,omitempty"`
+	CompositeKind     CompositeKind   `json:"compositeKind,omitempty"`
+	SiblingIDs        []string        `json:"siblingIds,omitempty"`
+	NestedSelections  []selectionJSON `json:"nestedSelections,omitempty"`
+}
+
+func (l *SelectionLog) MarshalJSON() ([]byte, error) {
+	return json.Marshal(selectionLogJSON{
+		Selections: newSelectionsJSON(l.selections),
+		Undone:     newSelectionsJSON(l.undone),
+	})
+}
+
+func (l *SelectionLog) UnmarshalJSON(data []byte) error {
+	var logJSON selectionLogJSON
+	if err := json.Unmarshal(data, &logJSON); err != nil {
+		return errors.Errorf("%w: invalid selection log: %v", puanerror.InvalidArgument, err)
+	}
+
+	selections, err := newSelectionsFromJSON(logJSON.Selections)
+	if err != nil {
+		return err
+	}
+
+	undone, err := newSelectionsFromJSON(logJSON.Undone)
+	if err != nil {
+		return err
+	}
+
+	l.selections = selections
+	l.undone = undone
+
+	return nil
+}
+
+func newSelectionsJSON(selections Selections) []selectionJSON {
+	if len(selections) == 0 {
+		return nil
+	}
+
+	selectionsJSON := make([]selectionJSON, len(selections))
+	for i, selection := range selections {
+		selectionsJSON[i] = selectionJSON{
+			ID:                selection.id,
+			Action:            selection.action,
+			SubSelectionIDs:   selection.subSelectionIDs,
+			ExclusiveGroupIDs: selection.exclusiveGroupIDs,
+			Hard:              selection.hard,
+			CompositeKind:     selection.compositeKind,
+			SiblingIDs:        selection.siblingIDs,
+			NestedSelections:  newSelectionsJSON(selection.nestedSelections),
+		}
+	}
+
+	return selectionsJSON
+}
+
+func newSelectionsFromJSON(selectionsJSON []selectionJSON) (Selections, error) {
+	if len(selectionsJSON) == 0 {
+		return nil, nil
+	}
+
+	selections := make(Selections, len(selectionsJSON))
+	for i, selectionJSON := range selectionsJSON {
+		switch selectionJSON.Action {
+		case ADD, REMOVE, SET_EXCLUSIVE, TOGGLE, REQUIRE:
+		default:
+			return nil, errors.Errorf(
+				"%w: unknown action '%s' of selection %s",
+				puanerror.InvalidArgument,
+				selectionJSON.Action,
+				selectionJSON.ID,
+			)
+		}
+
+		nested, err := newSelectionsFromJSON(selectionJSON.NestedSelections)
+		if err != nil {
+			return nil, err
+		}
+
+		builder := NewSelectionBuilder(selectionJSON.ID).
+			WithAction(selectionJSON.Action).
+			WithExclusiveGroup(selectionJSON.ExclusiveGroupIDs...).
+			WithHard(selectionJSON.Hard).
+			WithSiblings(selectionJSON.SiblingIDs...)
+		for _, subSelectionID := range selectionJSON.SubSelectionIDs {
+			builder.WithSubSelectionID(subSelectionID)
+		}
+		for _, nestedSelection := range nested {
+			builder.WithSubSelection(nestedSelection)
+		}
+		if selectionJSON.CompositeKind != "" {
+			builder.WithCompositeKind(selectionJSON.CompositeKind)
+		}
+
+		selections[i] = builder.Build()
+	}
+
+	return selections, nil
+}
@@ -0,0 +1,127 @@
+package puan
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+func Test_SelectionLog_UndoRedo_shouldStepThroughHistory(t *testing.T) {
+	x := NewSelectionBuilder("x").Build()
+	y := NewSelectionBuilder("y").Build()
+	log := NewSelectionLog(x)
+	log.Append(y)
+
+	undone, err := log.Undo()
+	require.NoError(t, err)
+	assert.Equal(t, y, undone)
+	assert.Equal(t, Selections{x}, log.Selections())
+
+	redone, err := log.Redo()
+	require.NoError(t, err)
+	assert.Equal(t, y, redone)
+	assert.Equal(t, Selections{x, y}, log.Selections())
+	assert.False(t, log.CanRedo())
+}
+
+func Test_SelectionLog_Append_shouldDiscardUndone(t *testing.T) {
+	log := NewSelectionLog(NewSelectionBuilder("x").Build())
+	_, err := log.Undo()
+	require.NoError(t, err)
+
+	log.Append(NewSelectionBuilder("y").Build())
+
+	_, err = log.Redo()
+	assert.ErrorIs(t, err, puanerror.InvalidOperation)
+}
+
+func Test_SelectionLog_Undo_givenEmptyLog_shouldReturnError(t *testing.T) {
+	_, err := NewSelectionLog().Undo()
+
+	assert.ErrorIs(t, err, puanerror.InvalidOperation)
+}
+
+func Test_SelectionLog_Compact_shouldKeepImpactingSelections(t *testing.T) {
+	log := NewSelectionLog(
+		NewSelectionBuilder("x").Build(),
+		NewSelectionBuilder("y").Build(),
+		NewSelectionBuilder("x").WithAction(TOGGLE).Build(),
+		NewSelectionBuilder("y").WithAction(REMOVE).Build(),
+		NewSelectionBuilder("z").Build(),
+	)
+	prepared := log.Selections().prepareForQuery()
+
+	log.Compact()
+
+	assert.Equal(
+		t,
+		Selections{
+			NewSelectionBuilder("x").WithAction(REMOVE).Build(),
+			NewSelectionBuilder("y").WithAction(REMOVE).Build(),
+			NewSelectionBuilder("z").Build(),
+		},
+		log.Selections(),
+	)
+	assert.Equal(t, prepared, log.Selections().prepareForQuery())
+}
+
+func Test_SelectionLog_Compact_givenToggleWithoutEarlierSelection_shouldKeepToggles(
+	t *testing.T,
+) {
+	log := NewSelectionLog(
+		NewSelectionBuilder("x").WithAction(TOGGLE).Build(),
+		NewSelectionBuilder("y").Build(),
+		NewSelectionBuilder("x").WithAction(TOGGLE).Build(),
+		NewSelectionBuilder("y").WithAction(REMOVE).Build(),
+	)
+
+	log.Compact()
+
+	assert.Equal(
+		t,
+		Selections{
+			NewSelectionBuilder("x").WithAction(TOGGLE).Build(),
+			NewSelectionBuilder("x").WithAction(TOGGLE).Build(),
+			NewSelectionBuilder("y").WithAction(REMOVE).Build(),
+		},
+		log.Selections(),
+	)
+}
+
+func Test_SelectionLog_JSON_shouldRoundTrip(t *testing.T) {
+	log := NewSelectionLog(
+		NewSelectionBuilder("x").
+			WithSubSelectionID("a").
+			WithSubSelection(NewSelectionBuilder("b").WithSubSelectionID("c").Build()).
+			WithCompositeKind(EXACTLY).
+			WithSiblings("d").
+			WithHard(true).
+			Build(),
+		NewSelectionBuilder("y").
+			WithAction(SET_EXCLUSIVE).
+			WithExclusiveGroup("z").
+			Build(),
+	)
+	_, err := log.Undo()
+	require.NoError(t, err)
+
+	data, err := json.Marshal(log)
+	require.NoError(t, err)
+
+	var actual SelectionLog
+	err = json.Unmarshal(data, &actual)
+
+	require.NoError(t, err)
+	assert.Equal(t, log, &actual)
+}
+
+func Test_SelectionLog_UnmarshalJSON_givenUnknownAction_shouldReturnError(t *testing.T) {
+	var log SelectionLog
+	err := json.Unmarshal([]byte(`{"selections":[{"id":"x","action":"PICK"}]}`), &log)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
@@ -0,0 +1,150 @@
+package puan
+
+import "fmt"
+
+const (
+	// The solution is what the selection asks for
+	HONOURED SelectionStatus = "HONOURED"
+	// A later selection took precedence over the selection
+	OVERRIDDEN SelectionStatus = "OVERRIDDEN"
+	// The rules do not allow the selection together with the other selections
+	NOT_ALLOWED SelectionStatus = "NOT_ALLOWED"
+)
+
+type SelectionStatus string
+
+type SelectionReport struct {
+	selection    Selection
+	status       SelectionStatus
+	overriddenBy *Selection
+}
+
+func (r SelectionReport) Selection() Selection {
+	return r.selection
+}
+
+func (r SelectionReport) Status() SelectionStatus {
+	return r.status
+}
+
+// The later selection taking precedence, nil unless the status is OVERRIDDEN
+func (r SelectionReport) OverriddenBy() *Selection {
+	return r.overriddenBy
+}
+
+func (r SelectionReport) Reason() string {
+	switch r.status {
+	case HONOURED:
+		return "selection is honoured by the solution"
+	case OVERRIDDEN:
+		return fmt.Sprintf(
+			"selection is overridden by later selection %s %v",
+			r.overriddenBy.action,
+			r.overriddenBy.IDs(),
+		)
+	default:
+		return "selection is not allowed by the rules together with the other selections"
+	}
+}
+
+// Part of a prepared selection, with the index of the selection it originates from
+type originatedSelection struct {
+	selection Selection
+	origin    int
+}
+
+// Reports, for each selection in order of occurrence, whether it is honoured
+// by the solution, overridden by a later selection, or not allowed by the rules.
+func (selectionsByOccurrence Selections) report(solution Solution) []SelectionReport {
+	return selectionsByOccurrence.reportAs(selectionsByOccurrence, solution)
+}
+
+// Reports like report, but of the given selections that the selections
+// are resolved from one by one, e.g. toggles resolved to adds or removes
+func (selectionsByOccurrence Selections) reportAs(
+	given Selections,
+	solution Solution,
+) []SelectionReport {
+	impacting, overriddenBy := selectionsByOccurrence.getImpactingByOrigin()
+
+	reports := make([]SelectionReport, len(selectionsByOccurrence))
+	for i := range selectionsByOccurrence {
+		reports[i] = SelectionReport{selection: given[i]}
+
+		parts, isImpacting := impacting[i]
+		if !isImpacting {
+			overriding := given[overriddenBy[i]]
+			reports[i].status = OVERRIDDEN
+			reports[i].overriddenBy = &overriding
+			continue
+		}
+
+		if parts.areHonouredBy(solution) {
+			reports[i].status = HONOURED
+		} else {
+			reports[i].status = NOT_ALLOWED
+		}
+	}
+
+	return reports
+}
+
+// Impacting parts of the prepared selections by the index of the selection
+// they originate from, like getImpacting. Selections without impacting parts
+// are mapped to the index of the later selection overriding them.
+func (selectionsByOccurrence Selections) getImpactingByOrigin() (
+	map[int]Selections,
+	map[int]int,
+) {
+	modified := selectionsByOccurrence.modifyForQueryWithOrigin()
+
+	overriddenBy := make(map[int]int)
+	impacting := make(map[int]Selections)
+	var filtered []originatedSelection
+	for i := len(modified) - 1; i >= 0; i-- {
+		candidate := modified[i]
+
+		overriding, isRedundant := candidate.findOverriding(filtered)
+		if !isRedundant {
+			filtered = append(filtered, candidate)
+			impacting[candidate.origin] = append(impacting[candidate.origin], candidate.selection)
+			continue
+		}
+
+		if overriding.origin != candidate.origin {
+			overriddenBy[candidate.origin] = overriding.origin
+		}
+	}
+
+	return impacting, overriddenBy
+}
+
+func (s Selections) modifyForQueryWithOrigin() []originatedSelection {
+	var modifiedSelections Selections
+	var originated []originatedSelection
+	for i, selection := range s {
+		modified := selection.modifyForQueryAfter(modifiedSelections)
+		modifiedSelections = append(modifiedSelections, modified...)
+
+		for _, part := range modified {
+			originated = append(originated, originatedSelection{
+				selection: part,
+				origin:    i,
+			})
+		}
+	}
+
+	return originated
+}
+
+func (s originatedSelection) findOverriding(
+	existing []originatedSelection,
+) (originatedSelection, bool) {
+	for _, existingSelection := range existing {
+		if existingSelection.selection.makesRedundant(s.selection) {
+			return existingSelection, true
+		}
+	}
+
+	return originatedSelection{}, false
+}
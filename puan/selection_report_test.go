@@ -0,0 +1,61 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Selections_report_givenLaterSelectionOfSameVariable_shouldBeOverridden(
+	t *testing.T,
+) {
+	add := NewSelectionBuilder("x").Build()
+	remove := NewSelectionBuilder("x").WithAction(REMOVE).Build()
+
+	reports := Selections{add, remove}.report(Solution{"x": 0})
+
+	require.Len(t, reports, 2)
+	assert.Equal(t, OVERRIDDEN, reports[0].Status())
+	assert.Equal(t, &remove, reports[0].OverriddenBy())
+	assert.Equal(t, HONOURED, reports[1].Status())
+	assert.Nil(t, reports[1].OverriddenBy())
+}
+
+func Test_Selections_report_givenSelectionNotInSolution_shouldBeNotAllowed(t *testing.T) {
+	selections := Selections{
+		NewSelectionBuilder("x").Build(),
+		NewSelectionBuilder("y").Build(),
+	}
+
+	reports := selections.report(Solution{"x": 0, "y": 1})
+
+	assert.Equal(t, NOT_ALLOWED, reports[0].Status())
+	assert.Equal(t, HONOURED, reports[1].Status())
+}
+
+func Test_Selections_report_givenToggle_shouldOverrideEarlierSelection(t *testing.T) {
+	selections := Selections{
+		NewSelectionBuilder("x").Build(),
+		NewSelectionBuilder("x").WithAction(TOGGLE).Build(),
+	}
+
+	reports := selections.report(Solution{"x": 0})
+
+	assert.Equal(t, OVERRIDDEN, reports[0].Status())
+	assert.Equal(t, HONOURED, reports[1].Status())
+}
+
+func Test_Selections_report_givenCompositeAfterPrimitive_shouldOverridePrimitive(
+	t *testing.T,
+) {
+	selections := Selections{
+		NewSelectionBuilder("x").Build(),
+		NewSelectionBuilder("x").WithSubSelectionID("y").Build(),
+	}
+
+	reports := selections.report(Solution{"x": 1, "y": 1})
+
+	assert.Equal(t, OVERRIDDEN, reports[0].Status())
+	assert.Equal(t, HONOURED, reports[1].Status())
+}
@@ -10,14 +10,43 @@ import (
 const (
 	ADD    Action = "ADD"
 	REMOVE Action = "REMOVE"
+	// Adds the selection and removes the other variables of its exclusive group,
+	// like a radio button
+	SET_EXCLUSIVE Action = "SET_EXCLUSIVE"
+	// Removes the selection if it is in the solution of the earlier
+	// selections, otherwise adds it
+	TOGGLE Action = "TOGGLE"
+	// Adds the selection as a hard selection, see IsHard
+	REQUIRE Action = "REQUIRE"
 )
 
 type Action string
 
+const (
+	// The primary variable and all sub-selections
+	ALL_OF CompositeKind = "ALL_OF"
+	// The primary variable and at least one of the sub-selections
+	ANY_OF CompositeKind = "ANY_OF"
+	// The primary variable and all sub-selections, but none of the siblings
+	EXACTLY CompositeKind = "EXACTLY"
+)
+
+// How the sub-selections of a composite selection are combined
+type CompositeKind string
+
 type Selection struct {
 	id              string
 	subSelectionIDs []string
 	action          Action
+	// Variables excluded by a SET_EXCLUSIVE selection
+	exclusiveGroupIDs []string
+	// Assumed by the ruleset instead of weighted
+	hard          bool
+	compositeKind CompositeKind
+	// Variables not allowed by an EXACTLY composite
+	siblingIDs []string
+	// Composite sub-selections, combined like sub-selection variables
+	nestedSelections Selections
 }
 
 type Selections []Selection
@@ -51,8 +80,13 @@ func (s Selections) split() (Selections, Selections) {
 	}
 }
 
+// Hard selections must be met, or the query fails
+func (s Selection) IsHard() bool {
+	return s.hard || s.action == REQUIRE
+}
+
 func (s Selection) IsComposite() bool {
-	return len(s.subSelectionIDs) > 0
+	return len(s.subSelectionIDs) > 0 || len(s.nestedSelections) > 0
 }
 
 func newSelection(action Action, id string, subSelectionIDs []string) Selection {
@@ -60,17 +94,31 @@ func newSelection(action Action, id string, subSelectionIDs []string) Selection
 		id:              id,
 		subSelectionIDs: subSelectionIDs,
 		action:          action,
+		compositeKind:   ALL_OF,
 	}
 }
 
+// Copy of the selection with another action, keeping its composite
+func (s Selection) withAction(action Action) Selection {
+	modified := newSelection(action, s.id, s.subSelectionIDs)
+	modified.compositeKind = s.compositeKind
+	modified.siblingIDs = s.siblingIDs
+	modified.nestedSelections = s.nestedSelections
+	return modified
+}
+
 func (s Selection) ID() string {
 	return s.id
 }
 
+// Primary and sub-selection variables, including those of nested selections
 func (s Selection) IDs() []string {
 	ids := make([]string, len(s.subSelectionIDs)+1)
 	ids[0] = s.id
 	copy(ids[1:], s.subSelectionIDs)
+	for _, nested := range s.nestedSelections {
+		ids = append(ids, nested.IDs()...)
+	}
 	return ids
 }
 
@@ -81,6 +129,31 @@ func (s Selection) Hash() string {
 	for _, subID := range utils.Sorted(s.subSelectionIDs) {
 		h.Write([]byte(subID))
 	}
+	if s.hard {
+		h.Write([]byte("hard"))
+	}
+	if len(s.exclusiveGroupIDs) > 0 {
+		h.Write([]byte("|"))
+		for _, groupID := range utils.Sorted(s.exclusiveGroupIDs) {
+			h.Write([]byte(groupID))
+		}
+	}
+	if s.compositeKind != ALL_OF {
+		h.Write([]byte(s.compositeKind))
+		for _, siblingID := range utils.Sorted(s.siblingIDs) {
+			h.Write([]byte(siblingID))
+		}
+	}
+	if len(s.nestedSelections) > 0 {
+		var nestedHashes []string
+		for _, nested := range s.nestedSelections {
+			nestedHashes = append(nestedHashes, nested.Hash())
+		}
+		h.Write([]byte("("))
+		for _, nestedHash := range utils.Sorted(nestedHashes) {
+			h.Write([]byte(nestedHash))
+		}
+	}
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
@@ -110,6 +183,57 @@ func (s Selection) makesRedundant(other Selection) bool {
 	return prioritisedIsNotComposite
 }
 
+// Checks if the solution is what the selections ask for,
+// the latest selection taking precedence as in a query.
+func (s Selections) areHonouredBy(solution Solution) bool {
+	for _, selection := range s.prepareForQuery() {
+		if selection.action == REMOVE && solution.isSelected(selection.id) {
+			return false
+		}
+
+		if selection.action == ADD && !selection.isHonouredBy(solution) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Checks if the solution has the variables of an added selection,
+// combined as given by its composite kind
+func (s Selection) isHonouredBy(solution Solution) bool {
+	if !solution.isSelected(s.id) {
+		return false
+	}
+
+	nrOfSubSelections := len(s.subSelectionIDs) + len(s.nestedSelections)
+	nrOfHonoured := 0
+	for _, subSelectionID := range s.subSelectionIDs {
+		if solution.isSelected(subSelectionID) {
+			nrOfHonoured++
+		}
+	}
+	for _, nested := range s.nestedSelections {
+		if nested.isHonouredBy(solution) {
+			nrOfHonoured++
+		}
+	}
+
+	switch s.compositeKind {
+	case ANY_OF:
+		return nrOfSubSelections == 0 || nrOfHonoured > 0
+	case EXACTLY:
+		for _, siblingID := range s.siblingIDs {
+			if solution.isSelected(siblingID) {
+				return false
+			}
+		}
+		return nrOfHonoured == nrOfSubSelections
+	default:
+		return nrOfHonoured == nrOfSubSelections
+	}
+}
+
 // Prepares selections for a query.
 // Modifies, adds additional and cleans up redundant selections.
 func (selectionsByOccurrence Selections) prepareForQuery() Selections {
@@ -124,14 +248,82 @@ func (s Selections) modifyForQuery() Selections {
 	for _, selection := range s {
 		modifiedSelections = append(
 			modifiedSelections,
-			selection.modifyForQuery()...,
+			selection.modifyForQueryAfter(modifiedSelections)...,
 		)
 	}
 
 	return modifiedSelections
 }
 
+// Modifies the selection given the already modified earlier selections
+func (s Selection) modifyForQueryAfter(earlierSelections Selections) Selections {
+	selection := s
+	if s.action == TOGGLE {
+		// Toggles not resolved by earlier selections are resolved against
+		// the solution by SolutionCreator, see resolveToggles, and are
+		// only added here when prepared without a solution.
+		resolved, ok := s.resolveToggle(earlierSelections)
+		if !ok {
+			resolved = s.withAction(ADD)
+		}
+		selection = resolved
+	}
+
+	if s.action == REQUIRE {
+		selection = s.withAction(ADD)
+	}
+
+	modified := selection.modifyForQuery()
+	if s.IsHard() {
+		for i := range modified {
+			modified[i].hard = true
+		}
+	}
+
+	return modified
+}
+
+// A toggle removes the selection if the latest earlier selection
+// of the same variable adds it, and adds it if that selection removes it.
+// Returns false if none of the earlier selections is of the variable.
+func (s Selection) resolveToggle(earlierSelections Selections) (Selection, bool) {
+	for i := len(earlierSelections) - 1; i >= 0; i-- {
+		earlier := earlierSelections[i]
+		if earlier.id != s.id {
+			continue
+		}
+
+		action := ADD
+		if earlier.action != REMOVE {
+			action = REMOVE
+		}
+
+		return s.toggledTo(action), true
+	}
+
+	return s, false
+}
+
+// A toggle removes the selection if the solution has it, otherwise adds it
+func (s Selection) resolveToggleIn(solution Solution) Selection {
+	if s.isHonouredBy(solution) {
+		return s.toggledTo(REMOVE)
+	}
+
+	return s.toggledTo(ADD)
+}
+
+func (s Selection) toggledTo(action Action) Selection {
+	toggled := s.withAction(action)
+	toggled.hard = s.hard
+	return toggled
+}
+
 func (s Selection) modifyForQuery() Selections {
+	if s.action == SET_EXCLUSIVE {
+		return s.modifyExclusiveForQuery()
+	}
+
 	if s.action == REMOVE {
 		removeSelection := NewSelectionBuilder(s.id).
 			WithAction(REMOVE).
@@ -150,6 +342,44 @@ func (s Selection) modifyForQuery() Selections {
 	return Selections{s}
 }
 
+// Removes the other variables of the group before adding the selection,
+// so that the added selection takes precedence.
+func (s Selection) modifyExclusiveForQuery() Selections {
+	modifiedSelections := Selections{}
+	for _, groupID := range utils.Without(s.exclusiveGroupIDs, s.IDs()) {
+		removeSelection := NewSelectionBuilder(groupID).
+			WithAction(REMOVE).
+			Build()
+		modifiedSelections = append(modifiedSelections, removeSelection)
+	}
+
+	addSelection := s.withAction(ADD)
+
+	return append(modifiedSelections, addSelection.modifyForQuery()...)
+}
+
+// Hard selections of prepared selections
+func (s Selections) hardSelections() Selections {
+	return utils.Filter(s, func(selection Selection) bool {
+		return selection.IsHard()
+	})
+}
+
+// Hard selections are enforced by the ruleset, not weighted.
+// Used where each selection is solved on its own but on a shared ruleset.
+func (s Selections) withHardAsSoft() Selections {
+	modified := make(Selections, len(s))
+	for i, selection := range s {
+		modified[i] = selection
+		modified[i].hard = false
+		if selection.action == REQUIRE {
+			modified[i].action = ADD
+		}
+	}
+
+	return modified
+}
+
 func (selectionsByOccurrence Selections) getImpacting() Selections {
 	byPriority := selectionsByOccurrence.reverse()
 	impactingByPriority := byPriority.filterOutRedundant()
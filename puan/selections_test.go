@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_Selections_getImpacting(t *testing.T) {
@@ -557,3 +558,185 @@ func Test_Selection_Equals(t *testing.T) {
 		})
 	}
 }
+
+func Test_Selections_areHonouredBy(t *testing.T) {
+	selections := Selections{
+		NewSelectionBuilder("x").WithSubSelectionID("y").Build(),
+		NewSelectionBuilder("z").WithAction(REMOVE).Build(),
+	}
+
+	assert.True(t, selections.areHonouredBy(Solution{"x": 1, "y": 1, "z": 0}))
+	assert.False(t, selections.areHonouredBy(Solution{"x": 1, "y": 0, "z": 0}))
+	assert.False(t, selections.areHonouredBy(Solution{"x": 1, "y": 1, "z": 1}))
+}
+
+func Test_Selections_prepareForQuery_givenSetExclusive_shouldRemoveGroupSiblings(t *testing.T) {
+	selections := Selections{
+		NewSelectionBuilder("b").Build(),
+		NewSelectionBuilder("a").
+			WithAction(SET_EXCLUSIVE).
+			WithExclusiveGroup("a", "b", "c").
+			Build(),
+	}
+
+	prepared := selections.prepareForQuery()
+
+	want := Selections{
+		NewSelectionBuilder("b").WithAction(REMOVE).Build(),
+		NewSelectionBuilder("c").WithAction(REMOVE).Build(),
+		NewSelectionBuilder("a").Build(),
+	}
+	assert.Equal(t, want, prepared)
+}
+
+func Test_Selections_prepareForQuery_givenToggle(t *testing.T) {
+	theories := []struct {
+		name       string
+		selections Selections
+		want       Selections
+	}{
+		{
+			name: "toggle without earlier selection should add",
+			selections: Selections{
+				NewSelectionBuilder("a").WithAction(TOGGLE).Build(),
+			},
+			want: Selections{
+				NewSelectionBuilder("a").Build(),
+			},
+		},
+		{
+			name: "toggle of added should remove",
+			selections: Selections{
+				NewSelectionBuilder("a").Build(),
+				NewSelectionBuilder("a").WithAction(TOGGLE).Build(),
+			},
+			want: Selections{
+				NewSelectionBuilder("a").WithAction(REMOVE).Build(),
+			},
+		},
+		{
+			name: "toggle twice should add",
+			selections: Selections{
+				NewSelectionBuilder("a").Build(),
+				NewSelectionBuilder("a").WithAction(TOGGLE).Build(),
+				NewSelectionBuilder("a").WithAction(TOGGLE).Build(),
+			},
+			want: Selections{
+				NewSelectionBuilder("a").Build(),
+			},
+		},
+		{
+			name: "toggle of removed should add",
+			selections: Selections{
+				NewSelectionBuilder("a").WithAction(REMOVE).Build(),
+				NewSelectionBuilder("b").Build(),
+				NewSelectionBuilder("a").WithAction(TOGGLE).Build(),
+			},
+			want: Selections{
+				NewSelectionBuilder("b").Build(),
+				NewSelectionBuilder("a").Build(),
+			},
+		},
+	}
+
+	for _, tt := range theories {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.selections.prepareForQuery())
+		})
+	}
+}
+
+func Test_Selections_prepareForQuery_givenRequireAfterRemove_shouldKeepRequire(t *testing.T) {
+	selections := Selections{
+		NewSelectionBuilder("a").WithAction(REMOVE).Build(),
+		NewSelectionBuilder("a").WithAction(REQUIRE).Build(),
+	}
+
+	prepared := selections.prepareForQuery()
+
+	require.Len(t, prepared, 1)
+	assert.Equal(t, ADD, prepared[0].action)
+	assert.Equal(t, prepared, prepared.hardSelections())
+}
+
+func Test_Selection_Hash_givenExclusiveGroup_shouldDifferFromWithoutGroup(t *testing.T) {
+	withGroup := NewSelectionBuilder("x").
+		WithAction(SET_EXCLUSIVE).
+		WithExclusiveGroup("y").
+		Build()
+	withoutGroup := NewSelectionBuilder("x").WithAction(SET_EXCLUSIVE).Build()
+
+	assert.NotEqual(t, withGroup.Hash(), withoutGroup.Hash())
+}
+
+func Test_Selections_prepareForQuery_givenHardComposite_shouldKeepAllHard(t *testing.T) {
+	selections := Selections{
+		NewSelectionBuilder("a").WithSubSelectionID("b").WithHard(true).Build(),
+	}
+
+	prepared := selections.prepareForQuery()
+
+	require.Len(t, prepared, 2)
+	assert.Equal(t, prepared, prepared.hardSelections())
+}
+
+func Test_Selection_isHonouredBy_givenCompositeKinds(t *testing.T) {
+	theories := []struct {
+		name      string
+		selection Selection
+		solution  Solution
+		want      bool
+	}{
+		{
+			name: "any of with one sub-selection",
+			selection: NewSelectionBuilder("x").
+				WithSubSelectionID("a").
+				WithSubSelectionID("b").
+				WithCompositeKind(ANY_OF).
+				Build(),
+			solution: Solution{"x": 1, "a": 0, "b": 1},
+			want:     true,
+		},
+		{
+			name: "any of without sub-selections",
+			selection: NewSelectionBuilder("x").
+				WithSubSelectionID("a").
+				WithSubSelectionID("b").
+				WithCompositeKind(ANY_OF).
+				Build(),
+			solution: Solution{"x": 1, "a": 0, "b": 0},
+			want:     false,
+		},
+		{
+			name: "exactly with sibling",
+			selection: NewSelectionBuilder("x").
+				WithSubSelectionID("a").
+				WithCompositeKind(EXACTLY).
+				WithSiblings("b").
+				Build(),
+			solution: Solution{"x": 1, "a": 1, "b": 1},
+			want:     false,
+		},
+		{
+			name: "nested",
+			selection: NewSelectionBuilder("x").
+				WithSubSelection(NewSelectionBuilder("a").WithSubSelectionID("b").Build()).
+				Build(),
+			solution: Solution{"x": 1, "a": 1, "b": 0},
+			want:     false,
+		},
+	}
+
+	for _, tt := range theories {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.selection.isHonouredBy(tt.solution))
+		})
+	}
+}
+
+func Test_Selection_Hash_givenCompositeKind_shouldDifferFromAllOf(t *testing.T) {
+	allOf := NewSelectionBuilder("x").WithSubSelectionID("a").Build()
+	anyOf := NewSelectionBuilder("x").WithSubSelectionID("a").WithCompositeKind(ANY_OF).Build()
+
+	assert.NotEqual(t, allOf.Hash(), anyOf.Hash())
+}
@@ -43,14 +43,53 @@ func (s Solution) isSelected(variableID string) bool {
 	return s[variableID] == 1
 }
 
+func (s Solution) areSelected(variableIDs []string) bool {
+	for _, variableID := range variableIDs {
+		if !s.isSelected(variableID) {
+			return false
+		}
+	}
+
+	return true
+}
+
 type SolutionEnvelope struct {
-	solution Solution
+	solution         Solution
+	selectionReports []SelectionReport
+	suggestions      []Suggestion
+}
+
+func newSolutionEnvelope(solution Solution, selections Selections) SolutionEnvelope {
+	return newSolutionEnvelopeOfGiven(solution, selections, selections)
+}
+
+// Envelope reporting the given selections, that the selections are resolved from
+func newSolutionEnvelopeOfGiven(
+	solution Solution,
+	selections Selections,
+	given Selections,
+) SolutionEnvelope {
+	return SolutionEnvelope{
+		solution:         solution,
+		selectionReports: selections.reportAs(given, solution),
+	}
 }
 
 func (e SolutionEnvelope) Solution() Solution {
 	return e.solution
 }
 
+// Status of each selection of the query, in order of occurrence
+func (e SolutionEnvelope) SelectionReports() []SelectionReport {
+	return e.selectionReports
+}
+
+// Alternatives for selections not allowed by the rules,
+// empty unless enabled with SolutionCreator.WithSuggestions
+func (e SolutionEnvelope) Suggestions() []Suggestion {
+	return e.suggestions
+}
+
 type SolutionsBySelectionEnvelope struct {
 	solutionsBySelection map[string]SolutionBySelection
 }
@@ -60,7 +99,7 @@ func NewSolutionsBySelectionEnvelope(
 ) (SolutionsBySelectionEnvelope, error) {
 	solutionsBySelection := make(map[string]SolutionBySelection)
 	for _, solution := range solutions {
-		selectionHash := solution.selection.Hash()
+		selectionHash := solution.Selection().Hash()
 		if _, exists := solutionsBySelection[selectionHash]; exists {
 			return SolutionsBySelectionEnvelope{}, errors.Errorf(
 				"duplicate solution for selection: %v",
@@ -96,12 +135,24 @@ func (e SolutionsBySelectionEnvelope) GetSolutionBySelection(
 type SolutionBySelection struct {
 	selection Selection
 	solution  Solution
+	// Selection as given, when a toggle is resolved in selection
+	given Selection
 }
 
+// The selection as given in the query
 func (s SolutionBySelection) Selection() Selection {
+	if s.given.id != "" {
+		return s.given
+	}
+
 	return s.selection
 }
 
 func (s SolutionBySelection) Solution() Solution {
 	return s.solution
 }
+
+// Status of the selection in its solution
+func (s SolutionBySelection) Report() SelectionReport {
+	return Selections{s.selection}.reportAs(Selections{s.Selection()}, s.solution)[0]
+}
@@ -0,0 +1,289 @@
+package puan
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/weights"
+)
+
+const DefaultBatchConcurrency = 8
+
+type BatchResult struct {
+	envelope SolutionEnvelope
+	err      error
+}
+
+func (r BatchResult) Envelope() SolutionEnvelope {
+	return r.envelope
+}
+
+func (r BatchResult) Err() error {
+	return r.err
+}
+
+// Query in a batch. Queries solved in a single solver call can be
+// solved together with other queries sharing the same prepared
+// polyhedron, the rest are solved one by one as in Create.
+type batchItem struct {
+	index               int
+	query               SolutionQuery
+	dependentQuery      SolutionQuery
+	independentSolution Solution
+	// Nil unless solved in a single solver call, see newSingleCallSolverQuery
+	solverQuery *SolverQuery
+	// Components of the ruleset solved if decomposable,
+	// see SolutionQuery.findTouchedComponents
+	touched      []int
+	decomposable bool
+	err          error
+}
+
+// Queries of the same prepared ruleset with the same query key
+// are solved on the same polyhedron, see PreparedRuleset.newQueryKey
+type batchKey struct {
+	prepared *PreparedRuleset
+	query    string
+}
+
+// Solves many independent queries, giving the same envelopes as Create.
+// Queries sharing a prepared polyhedron are solved in a single
+// multi-weight solver call, the rest concurrently with at most
+// the batch concurrency of solver calls in flight, see WithBatchConcurrency.
+// Results are returned in the same order as the queries.
+func (c *SolutionCreator) CreateBatch(queries []SolutionQuery) []BatchResult {
+	results := make([]BatchResult, len(queries))
+
+	var jobs []func()
+	for _, group := range c.groupBatchQueries(queries, results) {
+		jobs = append(jobs, func() {
+			c.solveBatchGroup(group, results)
+		})
+	}
+
+	c.workers.run(jobs)
+
+	return results
+}
+
+func (c *SolutionCreator) groupBatchQueries(
+	queries []SolutionQuery,
+	results []BatchResult,
+) [][]batchItem {
+	queries = withSharedPreparedRulesets(queries)
+
+	items := make([]batchItem, len(queries))
+	jobs := make([]func(), len(queries))
+	for i, query := range queries {
+		jobs[i] = func() {
+			items[i] = c.newBatchItem(i, query)
+		}
+	}
+	c.workers.run(jobs)
+
+	var keys []batchKey
+	groups := make(map[batchKey][]batchItem)
+	for i, item := range items {
+		if item.err != nil {
+			err := c.explainSolveError(item.err, queries[i])
+			results[i] = BatchResult{err: err}
+			continue
+		}
+
+		key, ok := newBatchKey(item)
+		if !ok {
+			key = batchKey{query: fmt.Sprintf("single:%d", i)}
+		}
+
+		if _, exists := groups[key]; !exists {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], item)
+	}
+
+	grouped := make([][]batchItem, len(keys))
+	for i, key := range keys {
+		grouped[i] = groups[key]
+	}
+
+	return grouped
+}
+
+// Queries of the same ruleset share a prepared ruleset in the batch,
+// so that each modified ruleset is created once
+func withSharedPreparedRulesets(queries []SolutionQuery) []SolutionQuery {
+	prepared := make(map[uint64]*PreparedRuleset)
+	shared := make([]SolutionQuery, len(queries))
+	for i, query := range queries {
+		shared[i] = query
+		if query.prepared != nil || query.ruleset.cacheID == 0 {
+			continue
+		}
+
+		if _, ok := prepared[query.ruleset.cacheID]; !ok {
+			prepared[query.ruleset.cacheID] =
+				NewPreparedRuleset(query.ruleset, DefaultPreparedRulesetCapacity)
+		}
+
+		shared[i] = NewSolutionQueryBuilder().
+			fromQuery(query).
+			WithPreparedRuleset(prepared[query.ruleset.cacheID]).
+			Build()
+	}
+
+	return shared
+}
+
+func newBatchKey(item batchItem) (batchKey, bool) {
+	query := item.dependentQuery
+	if item.solverQuery == nil || query.prepared == nil {
+		return batchKey{}, false
+	}
+
+	key, err := query.prepared.newQueryKey(query.selections, query.from, query.to)
+	if err != nil {
+		return batchKey{}, false
+	}
+
+	return batchKey{prepared: query.prepared, query: key}, true
+}
+
+func (c *SolutionCreator) newBatchItem(index int, query SolutionQuery) batchItem {
+	query, err := c.prepareQuery(query)
+	if err != nil {
+		return batchItem{err: err}
+	}
+
+	dependentSelections, independentSelections :=
+		categorizeSelections(query.selections, query.ruleset.independentVariables)
+
+	dependentQuery := NewSolutionQueryBuilder().
+		fromQuery(query).
+		WithSelections(dependentSelections).
+		Build()
+
+	// Default solutions are served from the cache as in Create
+	var solverQuery *SolverQuery
+	var touched []int
+	decomposable := false
+	if !dependentQuery.isDefault() {
+		touched, decomposable = dependentQuery.findTouchedComponents()
+	}
+	if !dependentQuery.isDefault() && !decomposable {
+		solverQuery, err = c.newSingleCallSolverQuery(dependentQuery)
+		if err != nil {
+			return batchItem{err: err}
+		}
+	}
+
+	independentSolution := calculateIndependentSolution(
+		query.ruleset.independentVariables,
+		independentSelections,
+	)
+
+	return batchItem{
+		index:               index,
+		query:               query,
+		dependentQuery:      dependentQuery,
+		independentSolution: independentSolution,
+		solverQuery:         solverQuery,
+		touched:             touched,
+		decomposable:        decomposable,
+	}
+}
+
+func (c *SolutionCreator) solveBatchGroup(group []batchItem, results []BatchResult) {
+	if len(group) == 1 {
+		c.solveBatchItem(group[0], results)
+		return
+	}
+
+	solutions, err := c.SolveWithManyWeights(newBatchSolverQuery(group))
+	if err != nil {
+		// Solve one by one to find out which of the queries failed
+		for _, item := range group {
+			c.solveBatchItem(item, results)
+		}
+		return
+	}
+
+	for i, item := range group {
+		solution := item.solverQuery.restore(solutions[i])
+		primitiveSolution := item.dependentQuery.ruleset.RemoveSupportVariables(solution)
+		results[item.index] = c.newBatchResult(item, primitiveSolution)
+	}
+}
+
+func (c *SolutionCreator) solveBatchItem(item batchItem, results []BatchResult) {
+	var solution Solution
+	var err error
+	switch {
+	case item.solverQuery != nil:
+		solution, err = c.solveDependentSolution(item.dependentQuery, item.solverQuery)
+	case item.decomposable:
+		solution, err = c.calculateTouchedDependentSolution(item.dependentQuery, item.touched)
+	case item.dependentQuery.isDefault():
+		solution, err = c.calculateDependentSolution(item.dependentQuery)
+	default:
+		solution, err = c.calculateLargeDependentSolution(item.dependentQuery)
+	}
+
+	if err != nil {
+		err = c.explainSolveError(err, item.query)
+		results[item.index] = BatchResult{err: err}
+		return
+	}
+
+	results[item.index] = c.newBatchResult(item, solution)
+}
+
+func (c *SolutionCreator) newBatchResult(item batchItem, dependentSolution Solution) BatchResult {
+	solution := dependentSolution.merge(item.independentSolution)
+
+	return BatchResult{envelope: c.createEnvelope(item.query, solution)}
+}
+
+func newBatchSolverQuery(group []batchItem) *MultiWeightSolverQuery {
+	weightGroups := make([]weights.Weights, len(group))
+	for i, item := range group {
+		weightGroups[i] = item.solverQuery.weights
+	}
+
+	first := group[0].solverQuery
+	return NewMultiWeightSolverQuery(
+		first.polyhedron,
+		first.variables,
+		weightGroups,
+	)
+}
+
+// Bounds the solver calls of a solution creator, shared by batches and
+// by the selection groups they solve concurrently. The calling goroutine
+// is one of the workers, so jobs run inline when no other worker is free,
+// which keeps nested jobs from waiting on the jobs that run them.
+type workerPool struct {
+	slots chan struct{}
+}
+
+func newWorkerPool(concurrency int) *workerPool {
+	return &workerPool{slots: make(chan struct{}, max(concurrency, 1)-1)}
+}
+
+func (p *workerPool) run(jobs []func()) {
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		select {
+		case p.slots <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-p.slots }()
+				job()
+			}()
+		default:
+			job()
+		}
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,184 @@
+package puan
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+type countingSolverClient struct {
+	bruteForceSolverClient
+	solveCalls     int
+	manySolveCalls int
+}
+
+func (c *countingSolverClient) Solve(query *SolverQuery) (Solution, error) {
+	c.solveCalls++
+	return c.bruteForceSolverClient.Solve(query)
+}
+
+func (c *countingSolverClient) SolveWithManyWeights(
+	query *MultiWeightSolverQuery,
+) ([]Solution, error) {
+	c.manySolveCalls++
+	return c.bruteForceSolverClient.SolveWithManyWeights(query)
+}
+
+func Test_SolutionCreator_CreateBatch_givenSamePolyhedron_shouldSolveInOneCall(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	queries := []SolutionQuery{
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("x").Build()}).
+			Build(),
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("y").Build()}).
+			Build(),
+	}
+
+	client := &countingSolverClient{}
+	results := NewSolutionCreator(client).CreateBatch(queries)
+
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err())
+	require.NoError(t, results[1].Err())
+	assert.Equal(t, Solution{"x": 1, "y": 0, "z": 0}, results[0].Envelope().Solution())
+	assert.Equal(t, Solution{"x": 0, "y": 1, "z": 0}, results[1].Envelope().Solution())
+	assert.Equal(t, 1, client.manySolveCalls)
+	assert.Equal(t, 0, client.solveCalls)
+}
+
+func Test_SolutionCreator_CreateBatch_givenInvalidQuery_shouldReturnErrorInOrder(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	queries := []SolutionQuery{
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("unknown").Build()}).
+			Build(),
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("z").Build()}).
+			Build(),
+	}
+
+	client := &countingSolverClient{}
+	results := NewSolutionCreator(client).
+		WithBatchConcurrency(1).
+		CreateBatch(queries)
+
+	require.Len(t, results, 2)
+	assert.ErrorIs(t, results[0].Err(), puanerror.InvalidArgument)
+	require.NoError(t, results[1].Err())
+	assert.Equal(t, 1, results[1].Envelope().Solution()["z"])
+	assert.Equal(t, 1, client.solveCalls)
+}
+
+func Test_SolutionCreator_CreateBatch_givenDifferentCompositeSelections_shouldSolveSeparately(
+	t *testing.T,
+) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "z")
+	xor, _ := creator.SetXor("x", "y")
+	zImpliesX, _ := creator.SetImply("z", "x")
+	_ = creator.Assume(xor, zImpliesX)
+	ruleset, _ := creator.Create()
+	prepared := NewPreparedRuleset(ruleset, DefaultPreparedRulesetCapacity)
+
+	queries := []SolutionQuery{
+		NewSolutionQueryBuilder().
+			WithPreparedRuleset(prepared).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").WithSubSelectionID("z").Build(),
+			}).
+			Build(),
+		NewSolutionQueryBuilder().
+			WithPreparedRuleset(prepared).
+			WithSelections(Selections{NewSelectionBuilder("y").Build()}).
+			Build(),
+	}
+
+	client := &countingSolverClient{}
+	results := NewSolutionCreator(client).
+		WithBatchConcurrency(1).
+		CreateBatch(queries)
+
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err())
+	require.NoError(t, results[1].Err())
+	assert.Equal(t, Solution{"x": 1, "y": 0, "z": 1}, results[0].Envelope().Solution())
+	assert.Equal(t, Solution{"x": 0, "y": 1, "z": 0}, results[1].Envelope().Solution())
+	assert.Equal(t, 0, client.manySolveCalls)
+	assert.Equal(t, 2, client.solveCalls)
+}
+
+func Test_SolutionCreator_CreateBatch_shouldGiveSameEnvelopesAsCreate(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "a", "b")
+	xor, _ := creator.SetXor("x", "y")
+	aImpliesB, _ := creator.SetImply("a", "b")
+	_ = creator.Assume(xor, aImpliesB)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	x := NewSelectionBuilder("x").Build()
+	y := NewSelectionBuilder("y").Build()
+	a := NewSelectionBuilder("a").Build()
+	queries := []SolutionQuery{
+		NewSolutionQueryBuilder().WithRuleset(ruleset).Build(),
+		NewSolutionQueryBuilder().WithRuleset(ruleset).WithSelections(Selections{a}).Build(),
+		NewSolutionQueryBuilder().WithRuleset(ruleset).WithSelections(Selections{x, y}).Build(),
+	}
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{}).WithSuggestions(true)
+	results := solutionCreator.CreateBatch(queries)
+
+	require.Len(t, results, len(queries))
+	for i, query := range queries {
+		want, err := solutionCreator.Create(query)
+		require.NoError(t, err)
+		require.NoError(t, results[i].Err())
+		assert.Equal(t, want, results[i].Envelope())
+	}
+	assert.NotEmpty(t, results[2].Envelope().Suggestions())
+}
+
+func Test_workerPool_run_givenNestedJobs_shouldBoundRunningJobs(t *testing.T) {
+	pool := newWorkerPool(2)
+
+	var running, maxRunning, done atomic.Int32
+	leaf := func() {
+		current := running.Add(1)
+		for {
+			previous := maxRunning.Load()
+			if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		done.Add(1)
+	}
+
+	outer := make([]func(), 4)
+	for i := range outer {
+		outer[i] = func() {
+			pool.run([]func(){leaf, leaf, leaf})
+		}
+	}
+	pool.run(outer)
+
+	assert.Equal(t, int32(12), done.Load())
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+}
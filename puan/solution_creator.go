@@ -1,10 +1,12 @@
 package puan
 
 import (
+	"slices"
 	"time"
 
 	"github.com/go-errors/errors"
 
+	"github.com/ourstudio-se/puan-sdk-go/internal/cache"
 	"github.com/ourstudio-se/puan-sdk-go/internal/utils"
 	"github.com/ourstudio-se/puan-sdk-go/puanerror"
 )
@@ -16,7 +18,12 @@ type SolverClient interface {
 
 type SolutionCreator struct {
 	SolverClient
-	queryCreator *solverQueryCreator
+	queryCreator       *solverQueryCreator
+	workers            *workerPool
+	suggest            bool
+	validateComposites bool
+	// Solutions without selections of rulesets and of their components
+	defaultSolutions *cache.LRU[string, Solution]
 }
 
 func NewSolutionCreator(
@@ -24,28 +31,137 @@ func NewSolutionCreator(
 ) *SolutionCreator {
 	queryCreator := newSolverQueryCreator()
 	return &SolutionCreator{
-		SolverClient: client,
-		queryCreator: queryCreator,
+		SolverClient:     client,
+		queryCreator:     queryCreator,
+		workers:          newWorkerPool(DefaultBatchConcurrency),
+		defaultSolutions: cache.NewLRU[string, Solution](DefaultSolutionCacheCapacity),
 	}
 }
 
+// Simplifies the prepared ruleset before sending it to the solver,
+// see pldag.Presolve. Solutions are restored to include all variables.
+func (c *SolutionCreator) WithPresolve(enabled bool) *SolutionCreator {
+	c.queryCreator.presolve = enabled
+	// Ties may be broken otherwise when presolved
+	c.defaultSolutions.Purge()
+	return c
+}
+
+// Suggests alternatives in Create for selections not allowed by the rules,
+// see SolutionEnvelope.Suggestions. Each such selection is solved again
+// as hard, and with time enabled, the availability of the selections is
+// searched once beyond the end of the query.
+func (c *SolutionCreator) WithSuggestions(enabled bool) *SolutionCreator {
+	c.suggest = enabled
+	return c
+}
+
+// Validates composite selections against the ruleset before solving,
+// see ValidateCompositeSelections. Each distinct composite selection
+// of a query costs a solver call, checking that it can be met on its own.
+func (c *SolutionCreator) WithCompositeValidation(enabled bool) *SolutionCreator {
+	c.validateComposites = enabled
+	return c
+}
+
+// Sets the maximum number of concurrent solver calls in CreateBatch,
+// including those of selections solved concurrently within a query
+func (c *SolutionCreator) WithBatchConcurrency(concurrency int) *SolutionCreator {
+	c.workers = newWorkerPool(concurrency)
+	return c
+}
+
 func (c *SolutionCreator) Create(
 	query SolutionQuery,
 ) (SolutionEnvelope, error) {
-	err := query.validate()
+	query, err := c.prepareQuery(query)
 	if err != nil {
 		return SolutionEnvelope{}, err
 	}
 
 	solution, err := c.calculateSolution(query)
 	if err != nil {
-		err = updateSolveError(err, query.ruleset, query.from)
+		err = c.explainSolveError(err, query)
 		return SolutionEnvelope{}, err
 	}
 
-	return SolutionEnvelope{
-		solution: solution,
-	}, nil
+	return c.createEnvelope(query, solution), nil
+}
+
+// Validates the query and resolves its toggles
+func (c *SolutionCreator) prepareQuery(query SolutionQuery) (SolutionQuery, error) {
+	err := c.validate(query)
+	if err != nil {
+		return SolutionQuery{}, err
+	}
+
+	return c.resolveToggles(query)
+}
+
+// Resolves each toggle against the selections before it, or, if none of
+// them is of the toggled variable, against the solution of the selections
+// before the first such toggle. Toggling a preferred or implied variable
+// then removes it instead of adding it. The solution is found once for all
+// toggles, and served from the cache if there are no earlier selections.
+// Envelopes report the toggles as given, see SolutionQuery.givenSelections.
+func (c *SolutionCreator) resolveToggles(query SolutionQuery) (SolutionQuery, error) {
+	if !slices.ContainsFunc(query.selections, func(selection Selection) bool {
+		return selection.action == TOGGLE
+	}) {
+		return query, nil
+	}
+
+	resolved := slices.Clone(query.selections)
+	var current Solution
+	solved := false
+	for i, selection := range resolved {
+		if selection.action != TOGGLE {
+			continue
+		}
+
+		earlier := resolved[:i].modifyForQuery()
+		toggled, ok := selection.resolveToggle(earlier)
+		if !ok {
+			if !solved {
+				currentQuery := NewSolutionQueryBuilder().
+					fromQuery(query).
+					WithSelections(resolved[:i]).
+					Build()
+				solution, err := c.calculateSolution(currentQuery)
+				if err != nil {
+					return SolutionQuery{}, c.explainSolveError(err, currentQuery)
+				}
+				current = solution
+				solved = true
+			}
+
+			toggled = selection.resolveToggleIn(current)
+		}
+
+		resolved[i] = toggled
+	}
+
+	return NewSolutionQueryBuilder().
+		fromQuery(query).
+		withResolvedSelections(resolved, query.selections).
+		Build(), nil
+}
+
+// Envelope of the solution of the query, with suggestions if enabled
+func (c *SolutionCreator) createEnvelope(
+	query SolutionQuery,
+	solution Solution,
+) SolutionEnvelope {
+	envelope := newSolutionEnvelopeOfGiven(
+		solution,
+		query.selections,
+		query.reportedSelections(),
+	)
+	if c.suggest {
+		envelope.suggestions = c.suggestAlternatives(query, envelope.selectionReports)
+	}
+
+	return envelope
 }
 
 func (c *SolutionCreator) calculateSolution(
@@ -76,28 +192,126 @@ func (c *SolutionCreator) calculateSolution(
 func (c *SolutionCreator) calculateDependentSolution(
 	query SolutionQuery,
 ) (Solution, error) {
-	solverQuery, err := c.queryCreator.new(query)
+	if query.isDefault() {
+		return c.findDefaultSolution(query)
+	}
+
+	return c.calculateUncachedDependentSolution(query)
+}
+
+func (c *SolutionCreator) calculateUncachedDependentSolution(
+	query SolutionQuery,
+) (Solution, error) {
+	touched, decomposable := query.findTouchedComponents()
+	if decomposable {
+		return c.calculateTouchedDependentSolution(query, touched)
+	}
+
+	solverQuery, err := c.newSingleCallSolverQuery(query)
 	if err != nil {
 		return Solution{}, err
 	}
 
-	tooLarge := solverQuery.weights.WeightsTooLarge()
+	if solverQuery == nil {
+		return c.calculateLargeDependentSolution(query)
+	}
+
+	return c.solveDependentSolution(query, solverQuery)
+}
 
-	if tooLarge {
-		return c.calculateSplitDependentSolution(query)
+// Solver query of queries solved in a single solver call, or nil for
+// queries with too large weights. Queries that touch only some of the
+// components of the ruleset are solved on those, see findTouchedComponents.
+func (c *SolutionCreator) newSingleCallSolverQuery(query SolutionQuery) (*SolverQuery, error) {
+	solverQuery, err := c.queryCreator.new(query)
+	if err != nil {
+		return nil, err
 	}
 
+	if solverQuery.weights.WeightsTooLarge() {
+		return nil, nil
+	}
+
+	return solverQuery, nil
+}
+
+// Solves the query in a single solver call
+func (c *SolutionCreator) solveDependentSolution(
+	query SolutionQuery,
+	solverQuery *SolverQuery,
+) (Solution, error) {
 	solution, err := c.Solve(solverQuery)
 	if err != nil {
 		return Solution{}, err
 	}
 
+	solution = solverQuery.restore(solution)
 	primitiveSolution := query.ruleset.RemoveSupportVariables(solution)
 
 	return primitiveSolution, nil
 }
 
-// When weights are very large, we need to solve many times sequentially
+// Selections that are not connected through the rules are solved
+// concurrently on their own, with smaller weights. Otherwise the
+// selections are solved in tiers.
+func (c *SolutionCreator) calculateLargeDependentSolution(
+	query SolutionQuery,
+) (Solution, error) {
+	components := query.ruleset.newVariableComponents(query.selections)
+
+	groups := partitionSelections(query.selections, components)
+	if len(groups) > 1 {
+		return c.calculateDependentSolutionByComponent(query, groups, components)
+	}
+
+	return c.calculateTieredDependentSolution(query)
+}
+
+// When weights are too large for a single solver call, selections are
+// solved in tiers of priority, the most prioritised first. Each tier keeps
+// the objective value reached by earlier tiers, which gives the same solution
+// as the combined weights, in one solver call per tier.
+// Falls back to split solving if the tiers cannot be weighted either.
+func (c *SolutionCreator) calculateTieredDependentSolution(
+	query SolutionQuery,
+) (Solution, error) {
+	preparedRuleset, err := query.modifyRulesetForQuery()
+	if err != nil {
+		return Solution{}, err
+	}
+
+	tiers, err := newWeightTiers(preparedRuleset, query.selections, query.periodWeighting)
+	if err != nil {
+		return c.calculateSplitDependentSolution(query)
+	}
+
+	// Prepared rulesets are shared, so bounds are set on a copy
+	ruleset := preparedRuleset.copy()
+
+	var solution Solution
+	for i, tier := range tiers {
+		solverQuery := NewSolverQuery(ruleset.polyhedron, ruleset.dependentVariables, tier)
+		solution, err = c.Solve(solverQuery)
+		if err != nil {
+			return Solution{}, err
+		}
+
+		isLast := i == len(tiers)-1
+		if isLast {
+			break
+		}
+
+		err = ruleset.setObjectiveBound(tier, solution)
+		if err != nil {
+			return Solution{}, err
+		}
+	}
+
+	return query.ruleset.RemoveSupportVariables(solution), nil
+}
+
+// When weights are very large, and cannot be solved in tiers,
+// we need to solve many times sequentially
 //
 // 1. Split selections into prioritised and remaining
 // 2. Solve with prioritised selections
@@ -167,9 +381,11 @@ func (c *SolutionCreator) newRulesetWithAssumedSolution(
 }
 
 func calculateIndependentSolution(independentVariables []string, selections Selections) Solution {
+	preparedSelections := selections.prepareForQuery()
+
 	solution := make(Solution, len(independentVariables))
 	for _, variable := range independentVariables {
-		solution[variable] = independentSolutionValue(variable, selections)
+		solution[variable] = independentSolutionValue(variable, preparedSelections)
 	}
 
 	return solution
@@ -180,7 +396,7 @@ func independentSolutionValue(variableID string, selections Selections) int {
 	for i := len(selections) - 1; i >= 0; i-- {
 		selection := selections[i]
 		if selection.id == variableID {
-			if selection.action == ADD {
+			if selection.action == ADD || selection.action == REQUIRE {
 				return 1
 			}
 
@@ -233,7 +449,7 @@ func updateSolveError(
 func (c *SolutionCreator) CreateSolutionsBySelection(
 	query SolutionQuery,
 ) (SolutionsBySelectionEnvelope, error) {
-	err := query.validate()
+	query, err := c.prepareQuery(query)
 	if err != nil {
 		return SolutionsBySelectionEnvelope{}, err
 	}
@@ -275,13 +491,26 @@ func (c *SolutionCreator) calculateSolutionsBySelection(
 	solutions = append(solutions, dependentSolutions...)
 	solutions = append(solutions, independentSolutions...)
 
+	// Categorized as the selections, since toggles keep their variables
+	givenDependent, givenIndependent :=
+		categorizeSelections(query.reportedSelections(), query.ruleset.independentVariables)
+	given := append(givenDependent, givenIndependent...)
+	for i := range solutions {
+		solutions[i].given = given[i]
+	}
+
 	return solutions, nil
 }
 
 func (c *SolutionCreator) calculateDependentSolutionsBySelection(
 	query SolutionQuery,
 ) ([]SolutionBySelection, error) {
-	solverQuery, err := c.queryCreator.newSolutionsBySelectionQuery(query)
+	// The selections share one ruleset, so none of them can be assumed
+	softQuery := NewSolutionQueryBuilder().
+		fromQuery(query).
+		WithSelections(query.selections.withHardAsSoft()).
+		Build()
+	solverQuery, err := c.queryCreator.newSolutionsBySelectionQuery(softQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -291,6 +520,7 @@ func (c *SolutionCreator) calculateDependentSolutionsBySelection(
 		return nil, err
 	}
 
+	solutions = solverQuery.restore(solutions)
 	primitiveSolutions := query.ruleset.RemoveSupportVariablesForMany(solutions)
 
 	solutionsBySelection := make([]SolutionBySelection, len(solutions))
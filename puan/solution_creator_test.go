@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ourstudio-se/puan-sdk-go/internal/fake"
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
 )
 
 func Test_SolutionQuery_validateSelections_givenEmptySelection_shouldReturnNoError(
@@ -51,3 +53,373 @@ func Test_categorizeSelections(t *testing.T) {
 	assert.Len(t, independentSelections, 1)
 	assert.Equal(t, independentID, independentSelections[0].id)
 }
+
+func Test_SolutionCreator_Create_givenPresolve_shouldReturnSameSolution(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	queries := []SolutionQuery{
+		NewSolutionQueryBuilder().WithRuleset(ruleset).Build(),
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("y").Build()}).
+			Build(),
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").WithSubSelectionID("y").Build(),
+			}).
+			Build(),
+	}
+
+	for _, query := range queries {
+		want, err := NewSolutionCreator(bruteForceSolverClient{}).Create(query)
+		require.NoError(t, err)
+
+		got, err := NewSolutionCreator(bruteForceSolverClient{}).
+			WithPresolve(true).
+			Create(query)
+		require.NoError(t, err)
+
+		assert.Equal(t, want.Solution(), got.Solution())
+	}
+}
+
+func Test_SolutionCreator_CreateBatch_givenPresolve_shouldRestoreSolutions(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	queries := []SolutionQuery{
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("x").Build()}).
+			Build(),
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("y").Build()}).
+			Build(),
+	}
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+	presolvingCreator := NewSolutionCreator(bruteForceSolverClient{}).WithPresolve(true)
+
+	want := solutionCreator.CreateBatch(queries)
+	got := presolvingCreator.CreateBatch(queries)
+
+	require.Len(t, got, len(want))
+	for i := range want {
+		require.NoError(t, got[i].Err())
+		assert.Equal(t, want[i].Envelope().Solution(), got[i].Envelope().Solution())
+	}
+}
+
+func Test_SolutionCreator_Create_givenSetExclusive_shouldDeselectSiblings(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("a", "b", "c")
+	anyOf, _ := creator.SetOr("a", "b", "c")
+	_ = creator.Assume(anyOf)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	envelope, err := NewSolutionCreator(bruteForceSolverClient{}).Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("b").Build(),
+				NewSelectionBuilder("c").Build(),
+				NewSelectionBuilder("a").
+					WithAction(SET_EXCLUSIVE).
+					WithExclusiveGroup("a", "b", "c").
+					Build(),
+			}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, Solution{"a": 1, "b": 0, "c": 0}, envelope.Solution())
+}
+
+func Test_SolutionCreator_Create_givenRequire_shouldNotBeOverriddenByLaterSelection(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").WithAction(REQUIRE).Build(),
+				NewSelectionBuilder("y").Build(),
+			}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, envelope.Solution()["x"])
+	assert.Equal(t, 0, envelope.Solution()["y"])
+}
+
+func Test_SolutionCreator_Create_givenConflictingRequires_shouldReturnSolverFailed(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	_, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").WithAction(REQUIRE).Build(),
+				NewSelectionBuilder("y").WithAction(REQUIRE).Build(),
+			}).
+			Build(),
+	)
+
+	assert.ErrorIs(t, err, puanerror.SolverFailed)
+}
+
+func Test_SolutionCreator_Create_givenConflictingHardSelections_shouldExplainConflict(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	hardX := NewSelectionBuilder("x").WithHard(true).Build()
+	hardY := NewSelectionBuilder("y").WithHard(true).Build()
+	_, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				hardX,
+				NewSelectionBuilder("z").WithHard(true).Build(),
+				hardY,
+			}).
+			Build(),
+	)
+
+	assert.ErrorIs(t, err, puanerror.SelectionConflict)
+	assert.ErrorIs(t, err, puanerror.SolverFailed)
+
+	var conflictErr *SelectionConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, Selections{hardX, hardY}, conflictErr.ConflictingSelections())
+}
+
+func Test_SolutionCreator_Create_givenHardRemove_shouldNotBeOverriddenByLaterSelection(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").WithAction(REMOVE).WithHard(true).Build(),
+				NewSelectionBuilder("y").WithAction(REMOVE).Build(),
+			}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, envelope.Solution()["x"])
+	assert.Equal(t, 1, envelope.Solution()["y"])
+}
+
+func Test_SolutionCreator_Create_givenConflictingSoftSelections_shouldNotReturnConflict(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").WithHard(true).Build(),
+				NewSelectionBuilder("y").Build(),
+			}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, envelope.Solution()["x"])
+	assert.Equal(t, 0, envelope.Solution()["y"])
+}
+
+func Test_SolutionCreator_Create_shouldReportStatusOfEachSelection(t *testing.T) {
+	ruleset := newXorRuleset(t)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("z").Build(),
+				NewSelectionBuilder("x").WithAction(REQUIRE).Build(),
+				NewSelectionBuilder("y").Build(),
+				NewSelectionBuilder("z").WithAction(REMOVE).Build(),
+			}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	reports := envelope.SelectionReports()
+	require.Len(t, reports, 4)
+	assert.Equal(t, OVERRIDDEN, reports[0].Status())
+	assert.Equal(t, HONOURED, reports[1].Status())
+	assert.Equal(t, NOT_ALLOWED, reports[2].Status())
+	assert.Equal(t, HONOURED, reports[3].Status())
+}
+
+func Test_SolutionCreator_calculateTieredDependentSolution_shouldKeepEarlierTierObjective(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	client := &countingSolverClient{}
+	solutionCreator := NewSolutionCreator(client)
+
+	solution, err := solutionCreator.calculateTieredDependentSolution(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").Build(),
+				NewSelectionBuilder("y").Build(),
+			}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, Solution{"x": 0, "y": 1}, solution)
+	assert.Equal(t, 2, client.solveCalls)
+}
+
+func Test_SolutionCreator_Create_givenToggleOfIndependent_shouldRemoveIt(t *testing.T) {
+	ruleset := newXorRuleset(t)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("z").Build(),
+				NewSelectionBuilder("z").WithAction(TOGGLE).Build(),
+			}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, envelope.Solution()["z"])
+}
+
+func Test_SolutionCreator_Create_givenToggleOfPreferred_shouldRemoveIt(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y")
+	xor, _ := creator.SetXor("x", "y")
+	_ = creator.Assume(xor)
+	_ = creator.Prefer("x")
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").WithAction(TOGGLE).Build(),
+			}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, Solution{"x": 0, "y": 1}, envelope.Solution())
+}
+
+func Test_SolutionCreator_Create_givenToggleOfImplied_shouldRemoveIt(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "z")
+	imply, _ := creator.SetImply("x", "y")
+	_ = creator.Assume(imply)
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").Build(),
+				NewSelectionBuilder("y").WithAction(TOGGLE).Build(),
+			}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, envelope.Solution()["y"])
+	assert.Equal(t, 0, envelope.Solution()["x"])
+}
+
+func Test_SolutionCreator_Create_givenToggle_shouldReportItAsGiven(t *testing.T) {
+	ruleset := newXorRuleset(t)
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	add := NewSelectionBuilder("x").Build()
+	toggle := NewSelectionBuilder("x").WithAction(TOGGLE).Build()
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{add, toggle}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, envelope.Solution()["x"])
+	reports := envelope.SelectionReports()
+	require.Len(t, reports, 2)
+	assert.Equal(t, add, reports[0].Selection())
+	assert.Equal(t, OVERRIDDEN, reports[0].Status())
+	assert.Equal(t, &toggle, reports[0].OverriddenBy())
+	assert.Equal(t, toggle, reports[1].Selection())
+	assert.Equal(t, HONOURED, reports[1].Status())
+}
+
+func Test_SolutionCreator_Create_givenManyUnresolvedToggles_shouldSolveOnceForThem(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+	client := &countingSolverClient{}
+	solutionCreator := NewSolutionCreator(client)
+	defaultEnvelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().WithRuleset(ruleset).Build(),
+	)
+	require.NoError(t, err)
+	defaultSolution := defaultEnvelope.Solution()
+	client.solveCalls = 0
+
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").WithAction(TOGGLE).Build(),
+				NewSelectionBuilder("y").WithAction(TOGGLE).Build(),
+			}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1-defaultSolution["x"], envelope.Solution()["x"])
+	assert.Equal(t, 1-defaultSolution["y"], envelope.Solution()["y"])
+	// The default solution is cached, leaving only the solve of the query
+	assert.Equal(t, 1, client.solveCalls)
+}
@@ -0,0 +1,264 @@
+package puan
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/pldag"
+	"github.com/ourstudio-se/puan-sdk-go/internal/utils"
+)
+
+const (
+	// Changed because the variable was selected
+	SELECTED ChangeKind = "SELECTED"
+	// Changed because the variable was removed
+	REMOVED ChangeKind = "REMOVED"
+	// Changed by the rules, as a consequence of other changes
+	IMPLIED ChangeKind = "IMPLIED"
+)
+
+type ChangeKind string
+
+type VariableChange struct {
+	variable string
+	from     int
+	to       int
+	kind     ChangeKind
+	causes   []string
+}
+
+func (c VariableChange) Variable() string {
+	return c.variable
+}
+
+func (c VariableChange) From() int {
+	return c.from
+}
+
+func (c VariableChange) To() int {
+	return c.to
+}
+
+func (c VariableChange) IsAdded() bool {
+	return c.to == 1
+}
+
+func (c VariableChange) Kind() ChangeKind {
+	return c.kind
+}
+
+// Directly selected or removed variables connected to an implied
+// change through the rules of the ruleset. Empty for direct changes,
+// and for implied changes not connected to a direct change,
+// e.g. a change of period or preferred.
+func (c VariableChange) Causes() []string {
+	return c.causes
+}
+
+// Changed variables between two solutions, sorted by variable
+type SolutionDiff struct {
+	changes []VariableChange
+}
+
+func (d SolutionDiff) Changes() []VariableChange {
+	return d.changes
+}
+
+func (d SolutionDiff) Added() []string {
+	var added []string
+	for _, change := range d.changes {
+		if change.IsAdded() {
+			added = append(added, change.variable)
+		}
+	}
+
+	return added
+}
+
+func (d SolutionDiff) Removed() []string {
+	var removed []string
+	for _, change := range d.changes {
+		if !change.IsAdded() {
+			removed = append(removed, change.variable)
+		}
+	}
+
+	return removed
+}
+
+func (d SolutionDiff) IsEmpty() bool {
+	return len(d.changes) == 0
+}
+
+// Changes from the previous solution, e.g. a prior configuration,
+// to the solution of the envelope
+func (e SolutionEnvelope) DiffFrom(previous Solution, ruleset Ruleset) SolutionDiff {
+	selections := make(Selections, len(e.selectionReports))
+	for i, report := range e.selectionReports {
+		selections[i] = report.selection
+	}
+
+	return ruleset.DiffSolutions(previous, e.solution, selections)
+}
+
+// Changes from the previous solution if the selection is made,
+// e.g. to preview the effect of a candidate selection
+func (s SolutionBySelection) DiffFrom(previous Solution, ruleset Ruleset) SolutionDiff {
+	return ruleset.DiffSolutions(previous, s.solution, Selections{s.selection})
+}
+
+// Classifies each variable changed between the solutions as selected
+// or removed by the selections, or implied by the rules.
+// Variables missing in a solution are treated as not selected.
+func (r *Ruleset) DiffSolutions(
+	previous Solution,
+	current Solution,
+	selections Selections,
+) SolutionDiff {
+	prepared := selections.prepareForQuery()
+
+	variables := utils.Union(
+		slices.Collect(maps.Keys(previous)),
+		slices.Collect(maps.Keys(current)),
+	)
+
+	var changes []VariableChange
+	for _, variable := range utils.Sorted(variables) {
+		from, to := previous[variable], current[variable]
+		if from == to {
+			continue
+		}
+
+		changes = append(changes, VariableChange{
+			variable: variable,
+			from:     from,
+			to:       to,
+			kind:     changeKind(variable, to, prepared),
+		})
+	}
+
+	direct := make(map[string]bool)
+	for _, change := range changes {
+		if change.kind != IMPLIED {
+			direct[change.variable] = true
+		}
+	}
+
+	graph := r.findVariableGraph()
+	for i, change := range changes {
+		if change.kind == IMPLIED {
+			changes[i].causes = graph.findConnected(change.variable, direct)
+		}
+	}
+
+	return SolutionDiff{changes: changes}
+}
+
+func changeKind(variable string, to int, prepared Selections) ChangeKind {
+	for _, selection := range prepared {
+		if !utils.Contains(selection.IDs(), variable) {
+			continue
+		}
+
+		isRemoved := selection.action == REMOVE
+		if isRemoved && to == 0 && selection.id == variable {
+			return REMOVED
+		}
+
+		if !isRemoved && to == 1 {
+			return SELECTED
+		}
+	}
+
+	return IMPLIED
+}
+
+// Rows of the polyhedron sharing a support variable, i.e. not selectable,
+// are connected, since support variables connect the rules. A variable
+// is connected to the variables of the rows connected to its own rows.
+// Read only once created, so it is shared by copies of the ruleset.
+type variableGraph struct {
+	rowsByVariable map[string][]int
+	// Connected rows share the same root
+	rootByRow []int
+}
+
+// Connects the rows in a single pass over the polyhedron
+func newVariableGraph(
+	polyhedron *pldag.Polyhedron,
+	dependentVariables []string,
+	selectableVariables []string,
+	periodVariables TimeBoundVariables,
+) *variableGraph {
+	rowsByVariable := make(map[string][]int)
+	rows := polyhedron.Rows()
+	for i, row := range rows {
+		for _, column := range row.Columns() {
+			variable := dependentVariables[column]
+			rowsByVariable[variable] = append(rowsByVariable[variable], i)
+		}
+	}
+
+	selectable := make(map[string]bool)
+	for _, variable := range selectableVariables {
+		selectable[variable] = true
+	}
+	for _, variable := range periodVariables.ids() {
+		selectable[variable] = true
+	}
+
+	connected := utils.NewDisjointSet[int]()
+	for variable, variableRows := range rowsByVariable {
+		if !selectable[variable] {
+			connected.Union(variableRows...)
+		}
+	}
+
+	rootByRow := make([]int, len(rows))
+	for i := range rows {
+		rootByRow[i] = connected.Find(i)
+	}
+
+	return &variableGraph{
+		rowsByVariable: rowsByVariable,
+		rootByRow:      rootByRow,
+	}
+}
+
+// Graph of the ruleset, created again if the ruleset has been changed
+func (r *Ruleset) findVariableGraph() *variableGraph {
+	if r.variableGraph != nil {
+		return r.variableGraph
+	}
+
+	return newVariableGraph(
+		r.polyhedron,
+		r.dependentVariables,
+		r.selectableVariables,
+		r.periodVariables,
+	)
+}
+
+// Targets reachable from the variable through support variables
+func (g *variableGraph) findConnected(variable string, targets map[string]bool) []string {
+	roots := make(map[int]bool)
+	for _, row := range g.rowsByVariable[variable] {
+		roots[g.rootByRow[row]] = true
+	}
+
+	var connected []string
+	for target := range targets {
+		if target == variable {
+			continue
+		}
+
+		isConnected := slices.ContainsFunc(g.rowsByVariable[target], func(row int) bool {
+			return roots[g.rootByRow[row]]
+		})
+		if isConnected {
+			connected = append(connected, target)
+		}
+	}
+
+	return utils.Sorted(connected)
+}
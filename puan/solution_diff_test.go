@@ -0,0 +1,107 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/internal/pldag"
+)
+
+func Test_SolutionEnvelope_DiffFrom_shouldClassifyChanges(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "z")
+	imply, _ := creator.SetImply("x", "y")
+	_ = creator.Assume(imply)
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").Build(),
+				NewSelectionBuilder("z").WithAction(REMOVE).Build(),
+			}).
+			Build(),
+	)
+	require.NoError(t, err)
+
+	previous := Solution{"x": 0, "y": 0, "z": 1}
+	diff := envelope.DiffFrom(previous, ruleset)
+
+	assert.Equal(
+		t,
+		[]VariableChange{
+			{variable: "x", from: 0, to: 1, kind: SELECTED},
+			{variable: "y", from: 0, to: 1, kind: IMPLIED, causes: []string{"x"}},
+			{variable: "z", from: 1, to: 0, kind: REMOVED},
+		},
+		diff.Changes(),
+	)
+	assert.Equal(t, []string{"x", "y"}, diff.Added())
+	assert.Equal(t, []string{"z"}, diff.Removed())
+}
+
+func Test_SolutionEnvelope_DiffFrom_givenSameSolution_shouldBeEmpty(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "z")
+	imply, _ := creator.SetImply("x", "y")
+	_ = creator.Assume(imply)
+	ruleset, _ := creator.Create()
+
+	envelope := newSolutionEnvelope(Solution{"x": 1, "y": 1, "z": 0}, nil)
+
+	diff := envelope.DiffFrom(Solution{"x": 1, "y": 1, "z": 0}, ruleset)
+
+	assert.True(t, diff.IsEmpty())
+}
+
+func Test_SolutionBySelection_DiffFrom_shouldPreviewEffectOfSelection(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y", "z")
+	imply, _ := creator.SetImply("x", "y")
+	_ = creator.Assume(imply)
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	candidate := NewSelectionBuilder("x").Build()
+	envelope, err := solutionCreator.CreateSolutionsBySelection(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{candidate}).
+			Build(),
+	)
+	require.NoError(t, err)
+
+	solutionBySelection, err := envelope.GetSolutionBySelection(candidate)
+	require.NoError(t, err)
+
+	diff := solutionBySelection.DiffFrom(Solution{"x": 0, "y": 0, "z": 0}, ruleset)
+
+	assert.Equal(t, []string{"x", "y"}, diff.Added())
+	assert.Equal(t, IMPLIED, diff.Changes()[1].Kind())
+	assert.Equal(t, []string{"x"}, diff.Changes()[1].Causes())
+}
+
+func Test_Ruleset_DiffSolutions_givenChangedRuleset_shouldFindCausesOfChangedRules(t *testing.T) {
+	ruleset := newXorRuleset(t)
+	require.NotNil(t, ruleset.variableGraph)
+
+	constraint, err := pldag.NewAtLeastConstraint([]string{"x", "y"}, 2)
+	require.NoError(t, err)
+	err = ruleset.setConstraintIfNotExist(constraint)
+	require.NoError(t, err)
+	require.Nil(t, ruleset.variableGraph)
+
+	diff := ruleset.DiffSolutions(
+		Solution{"x": 1, "y": 0},
+		Solution{"x": 0, "y": 1},
+		Selections{NewSelectionBuilder("x").WithAction(REMOVE).Build()},
+	)
+
+	assert.Equal(t, []string{"x"}, diff.Changes()[1].Causes())
+}
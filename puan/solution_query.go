@@ -9,10 +9,32 @@ import (
 )
 
 type SolutionQuery struct {
-	selections Selections
-	ruleset    Ruleset
-	from       *time.Time
-	to         *time.Time
+	selections      Selections
+	ruleset         Ruleset
+	prepared        *PreparedRuleset
+	from            *time.Time
+	to              *time.Time
+	periodWeighting periodWeighting
+	// Selections as given when toggles are resolved in selections,
+	// see SolutionCreator.resolveToggles
+	givenSelections Selections
+}
+
+// Selections as given by the caller, one for each of the selections
+func (query SolutionQuery) reportedSelections() Selections {
+	if query.givenSelections != nil {
+		return query.givenSelections
+	}
+
+	return query.selections
+}
+
+func (query SolutionQuery) modifyRulesetForQuery() (Ruleset, error) {
+	if query.prepared != nil {
+		return query.prepared.modifyForQuery(query.selections, query.from, query.to)
+	}
+
+	return query.ruleset.modifyForQuery(query.selections, query.from, query.to)
 }
 
 func (query SolutionQuery) validate() error {
@@ -24,6 +46,10 @@ func (query SolutionQuery) validate() error {
 		return err
 	}
 
+	if err := query.periodWeighting.validate(); err != nil {
+		return err
+	}
+
 	if err := query.validateSelections(); err != nil {
 		return err
 	}
@@ -62,26 +88,143 @@ func (query SolutionQuery) validateSelections() error {
 			)
 		}
 
-		hasSubSelection := len(selection.subSelectionIDs) > 0
-		if hasSubSelection {
-			if utils.ContainsAny(selection.IDs(), query.ruleset.independentVariables) {
-				return errors.Errorf(
-					"%w: independent variables cannot be part of a composite selections: %v",
-					puanerror.InvalidArgument,
-					selection,
-				)
-			}
+		if err := query.validateExclusiveGroup(selection); err != nil {
+			return err
+		}
+
+		if err := query.validateComposite(selection); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (query SolutionQuery) validateComposite(selection Selection) error {
+	if !selection.IsComposite() {
+		if selection.compositeKind != ALL_OF || len(selection.siblingIDs) > 0 {
+			return errors.Errorf(
+				"%w: composite kind and siblings require sub-selections: %v",
+				puanerror.InvalidArgument,
+				selection,
+			)
+		}
+		return nil
+	}
+
+	switch selection.compositeKind {
+	case ALL_OF, ANY_OF, EXACTLY:
+	default:
+		return errors.Errorf(
+			"%w: unknown composite kind '%s'",
+			puanerror.InvalidArgument,
+			selection.compositeKind,
+		)
+	}
+
+	if selection.compositeKind != EXACTLY && len(selection.siblingIDs) > 0 {
+		return errors.Errorf(
+			"%w: siblings are only allowed for %s composites: %v",
+			puanerror.InvalidArgument,
+			EXACTLY,
+			selection,
+		)
+	}
+
+	if !utils.ContainsAll(query.ruleset.selectableVariables, selection.siblingIDs) {
+		return errors.Errorf(
+			"%w: siblings contain non-selectable variables: %v",
+			puanerror.InvalidArgument,
+			selection,
+		)
+	}
+
+	if utils.ContainsAny(selection.siblingIDs, selection.IDs()) {
+		return errors.Errorf(
+			"%w: siblings cannot be part of the composite selection: %v",
+			puanerror.InvalidArgument,
+			selection,
+		)
+	}
+
+	compositeIDs := append(selection.IDs(), selection.siblingIDs...)
+	if utils.ContainsAny(compositeIDs, query.ruleset.independentVariables) {
+		return errors.Errorf(
+			"%w: independent variables cannot be part of a composite selections: %v",
+			puanerror.InvalidArgument,
+			selection,
+		)
+	}
+
+	for _, nested := range selection.nestedSelections {
+		if nested.action != ADD || nested.hard || len(nested.exclusiveGroupIDs) > 0 {
+			return errors.Errorf(
+				"%w: nested selections must be plain %s selections: %v",
+				puanerror.InvalidArgument,
+				ADD,
+				nested,
+			)
+		}
+
+		if err := query.validateComposite(nested); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+func (query SolutionQuery) validateExclusiveGroup(selection Selection) error {
+	hasGroup := len(selection.exclusiveGroupIDs) > 0
+	if selection.action != SET_EXCLUSIVE {
+		if hasGroup {
+			return errors.Errorf(
+				"%w: exclusive group is only allowed for %s selections: %v",
+				puanerror.InvalidArgument,
+				SET_EXCLUSIVE,
+				selection,
+			)
+		}
+		return nil
+	}
+
+	if !hasGroup {
+		return errors.Errorf(
+			"%w: exclusive group is required for %s selections: %v",
+			puanerror.InvalidArgument,
+			SET_EXCLUSIVE,
+			selection,
+		)
+	}
+
+	if !utils.ContainsAll(query.ruleset.selectableVariables, selection.exclusiveGroupIDs) {
+		return errors.Errorf(
+			"%w: exclusive group contains non-selectable variables: %v",
+			puanerror.InvalidArgument,
+			selection,
+		)
+	}
+
+	groupIDs := append(selection.IDs(), selection.exclusiveGroupIDs...)
+	if utils.ContainsAny(groupIDs, query.ruleset.independentVariables) {
+		return errors.Errorf(
+			"%w: independent variables cannot be part of exclusive groups: %v",
+			puanerror.InvalidArgument,
+			selection,
+		)
+	}
+
+	return nil
+}
+
 type SolutionQueryBuilder struct {
-	selections Selections
-	ruleset    Ruleset
-	from       *time.Time
-	to         *time.Time
+	selections      Selections
+	ruleset         Ruleset
+	prepared        *PreparedRuleset
+	from            *time.Time
+	to              *time.Time
+	periodWeighting periodWeighting
+	givenSelections Selections
 }
 
 func NewSolutionQueryBuilder() *SolutionQueryBuilder {
@@ -93,18 +236,43 @@ func (b *SolutionQueryBuilder) fromQuery(
 ) *SolutionQueryBuilder {
 	b.selections = query.selections
 	b.ruleset = query.ruleset
+	b.prepared = query.prepared
 	b.from = query.from
 	b.to = query.to
+	b.periodWeighting = query.periodWeighting
+	b.givenSelections = query.givenSelections
 	return b
 }
 
 func (b *SolutionQueryBuilder) WithSelections(selections Selections) *SolutionQueryBuilder {
 	b.selections = selections
+	b.givenSelections = nil
+	return b
+}
+
+// Selections resolved from the given ones, reported as the given ones
+func (b *SolutionQueryBuilder) withResolvedSelections(
+	resolved Selections,
+	given Selections,
+) *SolutionQueryBuilder {
+	b.selections = resolved
+	b.givenSelections = given
 	return b
 }
 
 func (b *SolutionQueryBuilder) WithRuleset(ruleset Ruleset) *SolutionQueryBuilder {
 	b.ruleset = ruleset
+	b.prepared = nil
+	return b
+}
+
+// Uses the ruleset of the prepared ruleset,
+// reusing rulesets modified for earlier queries
+func (b *SolutionQueryBuilder) WithPreparedRuleset(
+	prepared *PreparedRuleset,
+) *SolutionQueryBuilder {
+	b.ruleset = prepared.ruleset
+	b.prepared = prepared
 	return b
 }
 
@@ -118,11 +286,37 @@ func (b *SolutionQueryBuilder) WithTo(to *time.Time) *SolutionQueryBuilder {
 	return b
 }
 
+// Which period to prefer when several are possible, defaults to EARLIEST.
+// CLOSEST requires a target time, see WithTargetTime.
+func (b *SolutionQueryBuilder) WithPeriodDirection(
+	direction PeriodDirection,
+) *SolutionQueryBuilder {
+	b.periodWeighting.direction = direction
+	return b
+}
+
+func (b *SolutionQueryBuilder) WithTargetTime(target *time.Time) *SolutionQueryBuilder {
+	b.periodWeighting.target = target
+	return b
+}
+
+// Lets preferreds outweigh the period direction, e.g. a later period
+// is chosen if that satisfies more preferreds.
+func (b *SolutionQueryBuilder) WithPreferredsBeforePeriods(
+	enabled bool,
+) *SolutionQueryBuilder {
+	b.periodWeighting.preferredsFirst = enabled
+	return b
+}
+
 func (b *SolutionQueryBuilder) Build() SolutionQuery {
 	return SolutionQuery{
-		selections: b.selections,
-		ruleset:    b.ruleset,
-		from:       b.from,
-		to:         b.to,
+		selections:      b.selections,
+		ruleset:         b.ruleset,
+		prepared:        b.prepared,
+		from:            b.from,
+		to:              b.to,
+		periodWeighting: b.periodWeighting,
+		givenSelections: b.givenSelections,
 	}
 }
@@ -141,3 +141,98 @@ func Test_SolutionQuery_validateSelections_givenNotExistingID_shouldReturnError(
 
 	assert.Error(t, err)
 }
+
+func Test_SolutionQuery_validateSelections_givenSetExclusiveWithoutGroup_shouldReturnError(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	query := NewSolutionQueryBuilder().
+		WithRuleset(ruleset).
+		WithSelections(Selections{
+			NewSelectionBuilder("x").WithAction(SET_EXCLUSIVE).Build(),
+		}).
+		Build()
+
+	err := query.validateSelections()
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_SolutionQuery_validateSelections_givenGroupWithoutSetExclusive_shouldReturnError(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	query := NewSolutionQueryBuilder().
+		WithRuleset(ruleset).
+		WithSelections(Selections{
+			NewSelectionBuilder("x").WithExclusiveGroup("y").Build(),
+		}).
+		Build()
+
+	err := query.validateSelections()
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_SolutionQuery_validateSelections_givenIndependentInExclusiveGroup_shouldReturnError(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	query := NewSolutionQueryBuilder().
+		WithRuleset(ruleset).
+		WithSelections(Selections{
+			NewSelectionBuilder("x").
+				WithAction(SET_EXCLUSIVE).
+				WithExclusiveGroup("x", "z").
+				Build(),
+		}).
+		Build()
+
+	err := query.validateSelections()
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_SolutionQuery_validateSelections_givenInvalidComposite_shouldReturnError(
+	t *testing.T,
+) {
+	selections := map[string]Selection{
+		"siblings without exactly": NewSelectionBuilder("x").
+			WithSubSelectionID("y").
+			WithSiblings("z").
+			Build(),
+		"kind without sub-selections": NewSelectionBuilder("x").
+			WithCompositeKind(ANY_OF).
+			Build(),
+		"sibling in composite": NewSelectionBuilder("x").
+			WithSubSelectionID("y").
+			WithCompositeKind(EXACTLY).
+			WithSiblings("y").
+			Build(),
+		"nested remove": NewSelectionBuilder("x").
+			WithSubSelection(NewSelectionBuilder("y").WithAction(REMOVE).Build()).
+			Build(),
+		"unknown kind": NewSelectionBuilder("x").
+			WithSubSelectionID("y").
+			WithCompositeKind("NONE_OF").
+			Build(),
+	}
+
+	for name, selection := range selections {
+		t.Run(name, func(t *testing.T) {
+			ruleset := newXorRuleset(t)
+
+			query := NewSolutionQueryBuilder().
+				WithRuleset(ruleset).
+				WithSelections(Selections{selection}).
+				Build()
+
+			err := query.validateSelections()
+
+			assert.ErrorIs(t, err, puanerror.InvalidArgument)
+		})
+	}
+}
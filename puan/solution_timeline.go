@@ -0,0 +1,185 @@
+package puan
+
+import (
+	"maps"
+
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+// Optimal configuration during a period of the ruleset
+type TimelineEntry struct {
+	period   Period
+	solution Solution
+}
+
+func (e TimelineEntry) Period() Period {
+	return e.period
+}
+
+func (e TimelineEntry) Solution() Solution {
+	return e.solution
+}
+
+type SolutionTimelineEnvelope struct {
+	entries []TimelineEntry
+}
+
+// Entries sorted by period. Adjacent periods with identical configurations
+// are merged, and periods without any valid configuration are left out.
+func (e SolutionTimelineEnvelope) Entries() []TimelineEntry {
+	return e.entries
+}
+
+// Finds the optimal configuration for the selections in every period
+// of the ruleset, limited by from and to of the query.
+func (c *SolutionCreator) CreateTimeline(
+	query SolutionQuery,
+) (SolutionTimelineEnvelope, error) {
+	query, err := c.prepareQuery(query)
+	if err != nil {
+		return SolutionTimelineEnvelope{}, err
+	}
+
+	if query.ruleset.timeDisabled() {
+		return SolutionTimelineEnvelope{}, errors.Errorf(
+			"%w: time support not enabled for ruleset",
+			puanerror.InvalidOperation,
+		)
+	}
+
+	entries, err := c.calculateTimeline(query)
+	if err != nil {
+		err = c.explainSolveError(err, query)
+		return SolutionTimelineEnvelope{}, err
+	}
+
+	return SolutionTimelineEnvelope{
+		entries: mergeTimelineEntries(entries),
+	}, nil
+}
+
+func (c *SolutionCreator) calculateTimeline(
+	query SolutionQuery,
+) ([]TimelineEntry, error) {
+	dependentSelections, independentSelections :=
+		categorizeSelections(query.selections, query.ruleset.independentVariables)
+
+	dependentQuery := NewSolutionQueryBuilder().
+		fromQuery(query).
+		WithSelections(dependentSelections).
+		Build()
+	periodVariables := query.ruleset.periodVariables.between(query.from, query.to)
+	dependentSolutions, err := c.calculateDependentTimeline(dependentQuery, periodVariables)
+	if err != nil {
+		return nil, err
+	}
+
+	independentSolution := calculateIndependentSolution(
+		query.ruleset.independentVariables,
+		independentSelections,
+	)
+
+	var entries []TimelineEntry
+	for i, periodVariable := range periodVariables {
+		solution := dependentSolutions[i]
+		if solution == nil {
+			continue
+		}
+
+		solution = solution.
+			Extract(query.ruleset.selectableVariables...).
+			merge(independentSolution)
+
+		entries = append(entries, TimelineEntry{
+			period:   periodVariable.period,
+			solution: solution,
+		})
+	}
+
+	return entries, nil
+}
+
+// Solves once per period, with the period forced by weights.
+// A nil solution means that the period has no valid configuration.
+func (c *SolutionCreator) calculateDependentTimeline(
+	query SolutionQuery,
+	periodVariables TimeBoundVariables,
+) ([]Solution, error) {
+	solverQuery, err := c.queryCreator.newTimelineQuery(query, periodVariables.ids())
+	if err != nil {
+		return nil, err
+	}
+
+	if solverQuery.weightsTooLarge() {
+		return c.calculateDependentTimelineByPeriod(query, periodVariables)
+	}
+
+	solutions, err := c.SolveWithManyWeights(solverQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	solutions = solverQuery.restore(solutions)
+
+	timeline := make([]Solution, len(periodVariables))
+	for i, periodVariable := range periodVariables {
+		// The solver lands in another period if the forced one is infeasible
+		if solutions[i].isSelected(periodVariable.variable) {
+			timeline[i] = solutions[i]
+		}
+	}
+
+	return timeline, nil
+}
+
+// Fallback when forcing periods by weights makes the weights too large.
+// Each period is instead assumed in a ruleset of its own.
+func (c *SolutionCreator) calculateDependentTimelineByPeriod(
+	query SolutionQuery,
+	periodVariables TimeBoundVariables,
+) ([]Solution, error) {
+	timeline := make([]Solution, len(periodVariables))
+	for i, periodVariable := range periodVariables {
+		ruleset := query.ruleset.copy()
+		if err := ruleset.assume(periodVariable.variable); err != nil {
+			return nil, err
+		}
+
+		periodQuery := NewSolutionQueryBuilder().
+			fromQuery(query).
+			WithRuleset(ruleset).
+			Build()
+		solution, err := c.calculateDependentSolution(periodQuery)
+		if errors.Is(err, puanerror.SolverFailed) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		timeline[i] = solution
+	}
+
+	return timeline, nil
+}
+
+func mergeTimelineEntries(entries []TimelineEntry) []TimelineEntry {
+	var merged []TimelineEntry
+	for _, entry := range entries {
+		last := len(merged) - 1
+		isAdjacent := last >= 0 && entry.period.follows(merged[last].period)
+		if isAdjacent && maps.Equal(merged[last].solution, entry.solution) {
+			merged[last].period = newPeriodBetween(
+				merged[last].period.start(),
+				entry.period.end(),
+			)
+			continue
+		}
+
+		merged = append(merged, entry)
+	}
+
+	return merged
+}
@@ -0,0 +1,201 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+func Test_SolutionCreator_CreateTimeline_shouldReturnConfigurationPerPeriod(
+	t *testing.T,
+) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		"x",
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-10T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		"y",
+		newTestTime("2024-01-20T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	envelope, err := NewSolutionCreator(bruteForceSolverClient{}).CreateTimeline(
+		NewSolutionQueryBuilder().WithRuleset(ruleset).Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]TimelineEntry{
+			{
+				period:   newTestPeriod("2024-01-01T00:00:00Z", "2024-01-10T00:00:00Z"),
+				solution: Solution{"x": 1, "y": 0},
+			},
+			{
+				period:   newTestPeriod("2024-01-10T00:00:00Z", "2024-01-20T00:00:00Z"),
+				solution: Solution{"x": 0, "y": 0},
+			},
+			{
+				period:   newTestPeriod("2024-01-20T00:00:00Z", "2024-01-31T00:00:00Z"),
+				solution: Solution{"x": 0, "y": 1},
+			},
+		},
+		envelope.Entries(),
+	)
+}
+
+func Test_SolutionCreator_CreateTimeline_givenIdenticalAdjacentPeriods_shouldMerge(
+	t *testing.T,
+) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		"x",
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-10T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		"x",
+		newTestTime("2024-01-10T00:00:00Z"),
+		newTestTime("2024-01-20T00:00:00Z"),
+	)
+	ruleset, err := creator.Create()
+	require.NoError(t, err)
+
+	envelope, err := NewSolutionCreator(bruteForceSolverClient{}).CreateTimeline(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{NewSelectionBuilder("y").Build()}).
+			Build(),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]TimelineEntry{
+			{
+				period:   newTestPeriod("2024-01-01T00:00:00Z", "2024-01-20T00:00:00Z"),
+				solution: Solution{"x": 1, "y": 1},
+			},
+			{
+				period:   newTestPeriod("2024-01-20T00:00:00Z", "2024-01-31T00:00:00Z"),
+				solution: Solution{"x": 0, "y": 1},
+			},
+		},
+		envelope.Entries(),
+	)
+}
+
+func Test_SolutionCreator_CreateTimeline_givenInfeasiblePeriod_shouldLeaveItOut(
+	t *testing.T,
+) {
+	// Between the 10th and the 20th both x and not x are assumed
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x")
+	notX, _ := creator.SetNot("x")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		"x",
+		newTestTime("2024-01-10T00:00:00Z"),
+		newTestTime("2024-01-20T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-10T00:00:00Z"),
+		newTestTime("2024-01-20T00:00:00Z"),
+	)
+	ruleset, _ := creator.Create()
+
+	envelope, err := NewSolutionCreator(bruteForceSolverClient{}).CreateTimeline(
+		NewSolutionQueryBuilder().WithRuleset(ruleset).Build(),
+	)
+
+	require.NoError(t, err)
+	require.Len(t, envelope.Entries(), 2)
+	assert.Equal(
+		t,
+		newTestPeriod("2024-01-01T00:00:00Z", "2024-01-10T00:00:00Z"),
+		envelope.Entries()[0].Period(),
+	)
+	assert.Equal(
+		t,
+		newTestPeriod("2024-01-20T00:00:00Z", "2024-01-31T00:00:00Z"),
+		envelope.Entries()[1].Period(),
+	)
+}
+
+func Test_SolutionCreator_calculateDependentTimelineByPeriod_shouldEqualForcedByWeights(
+	t *testing.T,
+) {
+	// Between the 10th and the 20th both x and not x are assumed
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x")
+	notX, _ := creator.SetNot("x")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		"x",
+		newTestTime("2024-01-10T00:00:00Z"),
+		newTestTime("2024-01-20T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-10T00:00:00Z"),
+		newTestTime("2024-01-20T00:00:00Z"),
+	)
+	ruleset, _ := creator.Create()
+
+	query := NewSolutionQueryBuilder().WithRuleset(ruleset).Build()
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	want, err := solutionCreator.calculateDependentTimeline(query, ruleset.periodVariables)
+	require.NoError(t, err)
+
+	got, err := solutionCreator.calculateDependentTimelineByPeriod(
+		query,
+		ruleset.periodVariables,
+	)
+	require.NoError(t, err)
+
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.Equal(
+			t,
+			ruleset.RemoveSupportVariables(want[i]),
+			ruleset.RemoveSupportVariables(got[i]),
+		)
+	}
+}
+
+func Test_SolutionCreator_CreateTimeline_givenTimeDisabled_shouldReturnError(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	_, err := NewSolutionCreator(bruteForceSolverClient{}).CreateTimeline(
+		NewSolutionQueryBuilder().WithRuleset(ruleset).Build(),
+	)
+
+	assert.ErrorIs(t, err, puanerror.InvalidOperation)
+}
@@ -9,6 +9,7 @@ type SolverQuery struct {
 	polyhedron *pldag.Polyhedron
 	variables  []string
 	weights    weights.Weights
+	presolved  *pldag.Presolved
 }
 
 func NewSolverQuery(
@@ -35,10 +36,20 @@ func (q *SolverQuery) Weights() weights.Weights {
 	return q.weights
 }
 
+// Adds variables removed by presolve to the solution
+func (q *SolverQuery) restore(solution Solution) Solution {
+	if q.presolved == nil {
+		return solution
+	}
+
+	return q.presolved.Restore(solution)
+}
+
 type MultiWeightSolverQuery struct {
 	polyhedron   *pldag.Polyhedron
 	variables    []string
 	weightGroups []weights.Weights
+	presolved    *pldag.Presolved
 }
 
 func NewMultiWeightSolverQuery(
@@ -65,28 +76,73 @@ func (q *MultiWeightSolverQuery) WeightGroups() []weights.Weights {
 	return q.weightGroups
 }
 
-type solverQueryCreator struct{}
+func (q *MultiWeightSolverQuery) weightsTooLarge() bool {
+	for _, weightGroup := range q.weightGroups {
+		if weightGroup.WeightsTooLarge() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Adds variables removed by presolve to the solutions
+func (q *MultiWeightSolverQuery) restore(solutions []Solution) []Solution {
+	if q.presolved == nil {
+		return solutions
+	}
+
+	restored := make([]Solution, len(solutions))
+	for i, solution := range solutions {
+		restored[i] = q.presolved.Restore(solution)
+	}
+
+	return restored
+}
+
+type solverQueryCreator struct {
+	presolve bool
+}
 
 func newSolverQueryCreator() *solverQueryCreator {
 	return &solverQueryCreator{}
 }
 
 func (c *solverQueryCreator) new(query SolutionQuery) (*SolverQuery, error) {
-	preparedRuleset, err := query.ruleset.modifyForQuery(query.selections, query.from, query.to)
+	preparedRuleset, err := query.modifyRulesetForQuery()
 	if err != nil {
 		return nil, err
 	}
 
-	weights, err := newWeights(preparedRuleset, query.selections)
+	weights, err := newWeights(preparedRuleset, query.selections, query.periodWeighting)
 	if err != nil {
 		return nil, err
 	}
 
-	solverQuery := NewSolverQuery(
+	if !c.presolve {
+		solverQuery := NewSolverQuery(
+			preparedRuleset.polyhedron,
+			preparedRuleset.dependentVariables,
+			weights,
+		)
+
+		return solverQuery, nil
+	}
+
+	presolved, err := pldag.Presolve(
 		preparedRuleset.polyhedron,
 		preparedRuleset.dependentVariables,
-		weights,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	solverQuery := NewSolverQuery(
+		presolved.Polyhedron(),
+		presolved.Variables(),
+		presolved.ReduceWeights(weights),
+	)
+	solverQuery.presolved = &presolved
 
 	return solverQuery, nil
 }
@@ -94,21 +150,82 @@ func (c *solverQueryCreator) new(query SolutionQuery) (*SolverQuery, error) {
 func (c *solverQueryCreator) newSolutionsBySelectionQuery(
 	query SolutionQuery,
 ) (*MultiWeightSolverQuery, error) {
-	preparedRuleset, err := query.ruleset.modifyForQuery(query.selections, query.from, query.to)
+	preparedRuleset, err := query.modifyRulesetForQuery()
 	if err != nil {
 		return nil, err
 	}
 
-	weightGroups, err := c.calculateWeightsForSolutionsBySelection(preparedRuleset, query.selections)
+	weightGroups, err := c.calculateWeightsForSolutionsBySelection(
+		preparedRuleset,
+		query.selections,
+		query.periodWeighting,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	solverQuery := NewMultiWeightSolverQuery(
+	return c.newMultiWeightSolverQuery(preparedRuleset, weightGroups)
+}
+
+// One weight group per period, where the period is forced if feasible
+func (c *solverQueryCreator) newTimelineQuery(
+	query SolutionQuery,
+	periodIDs []string,
+) (*MultiWeightSolverQuery, error) {
+	preparedRuleset, err := query.modifyRulesetForQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	queryWeights, err := newWeights(preparedRuleset, query.selections, query.periodWeighting)
+	if err != nil {
+		return nil, err
+	}
+
+	weightGroups := make([]weights.Weights, len(periodIDs))
+	for i, periodID := range periodIDs {
+		weightGroups[i], err = queryWeights.Force(periodID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.newMultiWeightSolverQuery(preparedRuleset, weightGroups)
+}
+
+func (c *solverQueryCreator) newMultiWeightSolverQuery(
+	preparedRuleset Ruleset,
+	weightGroups []weights.Weights,
+) (*MultiWeightSolverQuery, error) {
+	if !c.presolve {
+		solverQuery := NewMultiWeightSolverQuery(
+			preparedRuleset.polyhedron,
+			preparedRuleset.dependentVariables,
+			weightGroups,
+		)
+
+		return solverQuery, nil
+	}
+
+	presolved, err := pldag.Presolve(
 		preparedRuleset.polyhedron,
 		preparedRuleset.dependentVariables,
-		weightGroups,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	reducedWeightGroups := make([]weights.Weights, len(weightGroups))
+	for i, weightGroup := range weightGroups {
+		reducedWeightGroups[i] = presolved.ReduceWeights(weightGroup)
+	}
+
+	solverQuery := NewMultiWeightSolverQuery(
+		presolved.Polyhedron(),
+		presolved.Variables(),
+		reducedWeightGroups,
+	)
+	solverQuery.presolved = &presolved
 
 	return solverQuery, nil
 }
@@ -116,12 +233,13 @@ func (c *solverQueryCreator) newSolutionsBySelectionQuery(
 func (c *solverQueryCreator) calculateWeightsForSolutionsBySelection(
 	ruleset Ruleset,
 	selections Selections,
+	weighting periodWeighting,
 ) ([]weights.Weights, error) {
 	weightsBySelection := make([]weights.Weights, len(selections))
 	for i, selection := range selections {
 		modifiedSelections := Selections{selection}.prepareForQuery()
 
-		weights, err := newWeights(ruleset, modifiedSelections)
+		weights, err := newWeights(ruleset, modifiedSelections, weighting)
 		if err != nil {
 			return nil, err
 		}
@@ -134,6 +252,7 @@ func (c *solverQueryCreator) calculateWeightsForSolutionsBySelection(
 func newWeights(
 	ruleset Ruleset,
 	selections Selections,
+	weighting periodWeighting,
 ) (weights.Weights, error) {
 	preparedSelections := selections.prepareForQuery()
 
@@ -144,11 +263,12 @@ func newWeights(
 		return nil, err
 	}
 
-	weights, err := weights.Calculate(
+	weights, err := weights.CalculateWithPeriodPriority(
 		dependentSelectableVariables,
 		weightSelections,
 		ruleset.preferredVariables,
-		ruleset.periodVariables.ids(),
+		weighting.orderPeriodIDs(ruleset.periodVariables),
+		weighting.periodPriority(),
 	)
 	if err != nil {
 		return nil, err
@@ -156,3 +276,25 @@ func newWeights(
 
 	return weights, nil
 }
+
+// Weights in tiers of priority, see weights.CalculateTiers
+func newWeightTiers(
+	ruleset Ruleset,
+	selections Selections,
+	weighting periodWeighting,
+) ([]weights.Weights, error) {
+	preparedSelections := selections.prepareForQuery()
+
+	weightSelections, err := ruleset.newWeightSelections(preparedSelections)
+	if err != nil {
+		return nil, err
+	}
+
+	return weights.CalculateTiers(
+		ruleset.dependentSelectableVariables(),
+		weightSelections,
+		ruleset.preferredVariables,
+		weighting.orderPeriodIDs(ruleset.periodVariables),
+		weighting.periodPriority(),
+	)
+}
@@ -0,0 +1,142 @@
+package puan
+
+const (
+	// The selection is allowed if other selections are removed
+	REMOVE_SELECTIONS SuggestionKind = "REMOVE_SELECTIONS"
+	// The selections are all allowed in another period
+	CHANGE_PERIOD SuggestionKind = "CHANGE_PERIOD"
+)
+
+type SuggestionKind string
+
+// Nearest alternative where a selection not allowed by the rules is honoured
+type Suggestion struct {
+	selection Selection
+	kind      SuggestionKind
+	removals  Selections
+	period    *Period
+	solution  Solution
+}
+
+// The selection not allowed in the solution of the query. A period
+// change allows all of them, and is given for the first one.
+func (s Suggestion) Selection() Selection {
+	return s.selection
+}
+
+func (s Suggestion) Kind() SuggestionKind {
+	return s.kind
+}
+
+// Selections to remove for the selection to be allowed,
+// empty unless the kind is REMOVE_SELECTIONS
+func (s Suggestion) Removals() Selections {
+	return s.removals
+}
+
+// Earliest period where all selections are allowed,
+// nil unless the kind is CHANGE_PERIOD
+func (s Suggestion) Period() *Period {
+	return s.period
+}
+
+// Solution if the suggestion is followed
+func (s Suggestion) Solution() Solution {
+	return s.solution
+}
+
+// Suggests, for each selection not allowed by the rules, the nearest
+// selections to remove, and the earliest period where all selections
+// are allowed. Suggestions that cannot be found are left out.
+func (c *SolutionCreator) suggestAlternatives(
+	query SolutionQuery,
+	reports []SelectionReport,
+) []Suggestion {
+	var suggestions []Suggestion
+	var notAllowed []int
+	for i, report := range reports {
+		if report.status != NOT_ALLOWED {
+			continue
+		}
+		notAllowed = append(notAllowed, i)
+
+		if removal, found := c.suggestRemovals(query, i); found {
+			suggestions = append(suggestions, removal)
+		}
+	}
+
+	if len(notAllowed) == 0 || query.ruleset.timeDisabled() {
+		return suggestions
+	}
+
+	if change, found := c.suggestPeriodChange(query, notAllowed[0]); found {
+		suggestions = append(suggestions, change)
+	}
+
+	return suggestions
+}
+
+// Solves with the selection as hard. The selections then not
+// allowed are the nearest set of conflicting selections to remove.
+func (c *SolutionCreator) suggestRemovals(
+	query SolutionQuery,
+	index int,
+) (Suggestion, bool) {
+	selections := make(Selections, len(query.selections))
+	copy(selections, query.selections)
+	selections[index].hard = true
+
+	relaxedQuery := NewSolutionQueryBuilder().
+		fromQuery(query).
+		WithSelections(selections).
+		Build()
+	solution, err := c.calculateSolution(relaxedQuery)
+	if err != nil {
+		return Suggestion{}, false
+	}
+
+	var removals Selections
+	for i, report := range selections.reportAs(query.reportedSelections(), solution) {
+		if i != index && report.status == NOT_ALLOWED {
+			removals = append(removals, report.selection)
+		}
+	}
+
+	if len(removals) == 0 {
+		return Suggestion{}, false
+	}
+
+	return Suggestion{
+		selection: query.reportedSelections()[index],
+		kind:      REMOVE_SELECTIONS,
+		removals:  removals,
+		solution:  solution,
+	}, true
+}
+
+// Searches for the earliest period where all selections are allowed,
+// relaxing the end of the period of the query. Passed periods stay forbidden.
+func (c *SolutionCreator) suggestPeriodChange(
+	query SolutionQuery,
+	index int,
+) (Suggestion, bool) {
+	relaxedQuery := NewSolutionQueryBuilder().
+		fromQuery(query).
+		WithTo(nil).
+		Build()
+	availability, err := c.findAvailability(relaxedQuery, false)
+	if err != nil {
+		return Suggestion{}, false
+	}
+
+	if !availability.IsAvailable() {
+		return Suggestion{}, false
+	}
+
+	return Suggestion{
+		selection: query.reportedSelections()[index],
+		kind:      CHANGE_PERIOD,
+		period:    availability.period,
+		solution:  availability.solution,
+	}, true
+}
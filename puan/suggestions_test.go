@@ -0,0 +1,144 @@
+package puan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SolutionCreator_Create_givenConflictingSelection_shouldSuggestRemovals(
+	t *testing.T,
+) {
+	ruleset := newXorRuleset(t)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{}).WithSuggestions(true)
+
+	x := NewSelectionBuilder("x").Build()
+	y := NewSelectionBuilder("y").Build()
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{x, y}).
+			Build(),
+	)
+	require.NoError(t, err)
+
+	suggestions := envelope.Suggestions()
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, x, suggestions[0].Selection())
+	assert.Equal(t, REMOVE_SELECTIONS, suggestions[0].Kind())
+	assert.Equal(t, Selections{y}, suggestions[0].Removals())
+	assert.Equal(t, 1, suggestions[0].Solution()["x"])
+	assert.Equal(t, 0, suggestions[0].Solution()["y"])
+}
+
+func Test_SolutionCreator_Create_givenSelectionNotAllowedBeforeTo_shouldSuggestPeriod(
+	t *testing.T,
+) {
+	// x is only available between the 10th and the 20th
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y")
+	notX, _ := creator.SetNot("x")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-10T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notX,
+		newTestTime("2024-01-20T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{}).WithSuggestions(true)
+
+	x := NewSelectionBuilder("x").Build()
+	to := newTestTime("2024-01-05T00:00:00Z")
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{x}).
+			WithTo(&to).
+			Build(),
+	)
+	require.NoError(t, err)
+
+	suggestions := envelope.Suggestions()
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, CHANGE_PERIOD, suggestions[0].Kind())
+	assert.Equal(
+		t,
+		newTestPeriod("2024-01-10T00:00:00Z", "2024-01-20T00:00:00Z"),
+		*suggestions[0].Period(),
+	)
+	assert.Equal(t, 1, suggestions[0].Solution()["x"])
+}
+
+func Test_SolutionCreator_Create_givenManySelectionsNotAllowedBeforeTo_shouldSuggestOnePeriod(
+	t *testing.T,
+) {
+	// x and y are only available between the 10th and the 20th
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y")
+	notXY, _ := creator.SetNot("x", "y")
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notXY,
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-10T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		notXY,
+		newTestTime("2024-01-20T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	ruleset, _ := creator.Create()
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{}).WithSuggestions(true)
+
+	x := NewSelectionBuilder("x").Build()
+	y := NewSelectionBuilder("y").Build()
+	to := newTestTime("2024-01-05T00:00:00Z")
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{x, y}).
+			WithTo(&to).
+			Build(),
+	)
+	require.NoError(t, err)
+
+	suggestions := envelope.Suggestions()
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, x, suggestions[0].Selection())
+	assert.Equal(t, CHANGE_PERIOD, suggestions[0].Kind())
+	assert.Equal(t, Solution{"x": 1, "y": 1}, suggestions[0].Solution().Extract("x", "y"))
+}
+
+func Test_SolutionCreator_Create_givenSuggestionsDisabled_shouldNotSuggest(t *testing.T) {
+	ruleset := newXorRuleset(t)
+
+	solutionCreator := NewSolutionCreator(bruteForceSolverClient{})
+
+	envelope, err := solutionCreator.Create(
+		NewSolutionQueryBuilder().
+			WithRuleset(ruleset).
+			WithSelections(Selections{
+				NewSelectionBuilder("x").Build(),
+				NewSelectionBuilder("y").Build(),
+			}).
+			Build(),
+	)
+	require.NoError(t, err)
+
+	assert.Empty(t, envelope.Suggestions())
+}
@@ -0,0 +1,130 @@
+package puan
+
+import (
+	"time"
+
+	"github.com/go-errors/errors"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+const (
+	SECOND Granularity = "SECOND"
+	MINUTE Granularity = "MINUTE"
+	HOUR   Granularity = "HOUR"
+	DAY    Granularity = "DAY"
+)
+
+// Precision of period edges, finer parts are truncated
+type Granularity string
+
+func (g Granularity) duration() (time.Duration, error) {
+	switch g {
+	case SECOND:
+		return time.Second, nil
+	case MINUTE:
+		return time.Minute, nil
+	case HOUR:
+		return time.Hour, nil
+	case DAY:
+		return 24 * time.Hour, nil
+	default:
+		return 0, errors.Errorf(
+			"%w: unknown granularity '%s'",
+			puanerror.InvalidArgument,
+			g,
+		)
+	}
+}
+
+// Snaps period edges to a granularity in a canonical time zone,
+// so that equal edges given in different ways partition equally.
+type timeNormalizer struct {
+	location    *time.Location
+	granularity Granularity
+}
+
+func newTimeNormalizer(location *time.Location, granularity Granularity) (timeNormalizer, error) {
+	if location == nil {
+		return timeNormalizer{}, errors.Errorf(
+			"%w: location is required",
+			puanerror.InvalidArgument,
+		)
+	}
+
+	if _, err := granularity.duration(); err != nil {
+		return timeNormalizer{}, err
+	}
+
+	return timeNormalizer{
+		location:    location,
+		granularity: granularity,
+	}, nil
+}
+
+func (n timeNormalizer) newPeriod(start, end periodEdge) (Period, error) {
+	normalizedStart, err := n.normalizeEdge(start)
+	if err != nil {
+		return Period{}, err
+	}
+
+	normalizedEnd, err := n.normalizeEdge(end)
+	if err != nil {
+		return Period{}, err
+	}
+
+	if normalizedEnd.compare(normalizedStart) <= 0 {
+		return Period{}, errors.Errorf(
+			"%w: period %v-%v is empty at granularity %s",
+			puanerror.InvalidArgument,
+			start,
+			end,
+			n.granularity,
+		)
+	}
+
+	return newPeriod(normalizedStart, normalizedEnd)
+}
+
+// Open ends have no time to normalize
+func (n timeNormalizer) normalizeEdge(edge periodEdge) (periodEdge, error) {
+	if edge.open != 0 {
+		return edge, nil
+	}
+
+	normalized, err := n.normalize(edge.time)
+	if err != nil {
+		return periodEdge{}, err
+	}
+
+	return newTimeEdge(normalized), nil
+}
+
+func (n timeNormalizer) normalize(t time.Time) (time.Time, error) {
+	local := t.In(n.location)
+	if n.granularity == DAY {
+		return n.startOfDay(local)
+	}
+
+	// Truncate in the offset of the instant itself, which is never ambiguous,
+	// also for zones with offsets that are not whole hours.
+	granularity, _ := n.granularity.duration()
+	_, offset := local.Zone()
+	shift := time.Duration(offset) * time.Second
+	return local.Add(shift).Truncate(granularity).Add(-shift), nil
+}
+
+// Midnight does not exist on days where daylight saving time starts at midnight
+func (n timeNormalizer) startOfDay(local time.Time) (time.Time, error) {
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, n.location)
+	if midnight.Hour() != 0 || midnight.Day() != local.Day() {
+		return time.Time{}, errors.Errorf(
+			"%w: start of day %s is ambiguous in %s",
+			puanerror.InvalidArgument,
+			local.Format(time.DateOnly),
+			n.location,
+		)
+	}
+
+	return midnight, nil
+}
@@ -0,0 +1,125 @@
+package puan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+func Test_RulesetCreator_NormalizeTime_givenMixedLocationsAndSeconds_shouldPartitionEqually(
+	t *testing.T,
+) {
+	creator := NewRulesetCreator()
+	_ = creator.AddPrimitives("x", "y")
+	require.NoError(t, creator.NormalizeTime(time.UTC, MINUTE))
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		"x",
+		newTestTime("2024-01-10T01:00:00+01:00"),
+		newTestTime("2024-01-20T00:00:00Z"),
+	)
+	_ = creator.AssumeInPeriod(
+		"y",
+		newTestTime("2024-01-10T00:00:30Z"),
+		newTestTime("2024-01-20T00:00:59Z"),
+	)
+
+	ruleset, err := creator.Create()
+
+	require.NoError(t, err)
+	assert.Len(t, ruleset.periodVariables, 3)
+	assert.True(
+		t,
+		ruleset.periodVariables[1].period.isEqual(
+			newTestPeriod("2024-01-10T00:00:00Z", "2024-01-20T00:00:00Z"),
+		),
+	)
+}
+
+func Test_RulesetCreator_NormalizeTime_givenDayGranularity_shouldSnapToLocalMidnight(
+	t *testing.T,
+) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	require.NoError(t, err)
+
+	creator := NewRulesetCreator()
+	require.NoError(t, creator.NormalizeTime(stockholm, DAY))
+	err = creator.EnableTime(
+		newTestTime("2024-01-01T12:00:00Z"),
+		newTestTime("2024-01-31T23:30:00Z"),
+	)
+	require.NoError(t, err)
+
+	assert.True(t, creator.period.from.Equal(newTestTime("2023-12-31T23:00:00Z")))
+	assert.True(t, creator.period.to.Equal(newTestTime("2024-01-31T23:00:00Z")))
+}
+
+func Test_RulesetCreator_NormalizeTime_givenPeriodShorterThanGranularity_shouldReturnError(
+	t *testing.T,
+) {
+	creator := NewRulesetCreator()
+	require.NoError(t, creator.NormalizeTime(time.UTC, HOUR))
+
+	err := creator.EnableTime(
+		newTestTime("2024-01-01T10:10:00Z"),
+		newTestTime("2024-01-01T10:50:00Z"),
+	)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_RulesetCreator_NormalizeTime_givenNonExistingMidnight_shouldReturnError(
+	t *testing.T,
+) {
+	// Daylight saving time starts at midnight 2024-09-08 in Santiago
+	santiago, err := time.LoadLocation("America/Santiago")
+	require.NoError(t, err)
+
+	creator := NewRulesetCreator()
+	require.NoError(t, creator.NormalizeTime(santiago, DAY))
+
+	err = creator.EnableTime(
+		newTestTime("2024-09-08T12:00:00Z"),
+		newTestTime("2024-09-20T12:00:00Z"),
+	)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_RulesetCreator_NormalizeTime_givenTimeEnabled_shouldReturnError(t *testing.T) {
+	creator := NewRulesetCreator()
+	_ = creator.EnableTime(
+		newTestTime("2024-01-01T00:00:00Z"),
+		newTestTime("2024-01-31T00:00:00Z"),
+	)
+
+	err := creator.NormalizeTime(time.UTC, DAY)
+
+	assert.ErrorIs(t, err, puanerror.InvalidOperation)
+}
+
+func Test_RulesetCreator_NormalizeTime_givenInvalidOptions_shouldReturnError(t *testing.T) {
+	creator := NewRulesetCreator()
+
+	assert.ErrorIs(t, creator.NormalizeTime(nil, DAY), puanerror.InvalidArgument)
+	assert.ErrorIs(t, creator.NormalizeTime(time.UTC, "WEEK"), puanerror.InvalidArgument)
+}
+
+func Test_timeNormalizer_newPeriod_givenOpenEnd_shouldKeepIt(t *testing.T) {
+	normalizer, err := newTimeNormalizer(time.UTC, DAY)
+	require.NoError(t, err)
+	from := newTestTime("2024-01-01T12:00:00Z")
+
+	actual, err := normalizer.newPeriod(newStartEdge(&from), newEndEdge(nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, newTestTime("2024-01-01T00:00:00Z"), actual.From())
+	assert.True(t, actual.IsOpenEnd())
+}
@@ -7,4 +7,6 @@ var (
 	InvalidOperation = errors.New("invalid operation")
 	SolverFailed     = errors.New("solver failed")
 	NotFound         = errors.New("not found")
+	// Hard selections cannot all be met
+	SelectionConflict = errors.New("selection conflict")
 )
@@ -0,0 +1,39 @@
+package solver
+
+import (
+	"github.com/ourstudio-se/puan-sdk-go/internal/gateway/inproc"
+	"github.com/ourstudio-se/puan-sdk-go/puan"
+)
+
+type inprocClient struct{}
+
+// Solves queries in process, without an external solver. The search is
+// exhaustive, so it is intended for small rulesets, e.g. in tests and
+// local development.
+func NewInprocClient() puan.SolverClient {
+	return inprocClient{}
+}
+
+func (c inprocClient) Solve(query *puan.SolverQuery) (puan.Solution, error) {
+	return inproc.Solve(query.Polyhedron(), query.Variables(), query.Weights())
+}
+
+func (c inprocClient) SolveWithManyWeights(
+	query *puan.MultiWeightSolverQuery,
+) ([]puan.Solution, error) {
+	solutions, err := inproc.SolveWithManyWeights(
+		query.Polyhedron(),
+		query.Variables(),
+		query.WeightGroups(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]puan.Solution, len(solutions))
+	for i, solution := range solutions {
+		converted[i] = solution
+	}
+
+	return converted, nil
+}
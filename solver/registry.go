@@ -0,0 +1,212 @@
+package solver
+
+import (
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-errors/errors"
+
+	glpk "github.com/ourstudio-se/puan-sdk-go/internal/gateway/glpk"
+	"github.com/ourstudio-se/puan-sdk-go/puan"
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+const (
+	GLPK_HTTP_SCHEME  = "glpk+http"
+	GLPK_HTTPS_SCHEME = "glpk+https"
+	INPROC_SCHEME     = "inproc"
+	REPLAY_SCHEME     = "replay"
+	// Prefix of any scheme, recording the answers of its backend to
+	// golden files, e.g. "record+glpk+http://host:9000?golden=testdata"
+	RECORD_PREFIX = "record+"
+)
+
+// Creates a solver client from the URL of a configuration string
+type Backend func(location *url.URL, options Options) (puan.SolverClient, error)
+
+// Settings of backends that are not part of the URL
+type Options struct {
+	// Overrides the apiKey query parameter of the GLPK HTTP gateway
+	APIKey string
+	// Defaults to a new http.Client
+	HTTPClient *http.Client
+}
+
+type registry struct {
+	mutex    sync.RWMutex
+	backends map[string]Backend
+}
+
+var backends = &registry{
+	backends: map[string]Backend{
+		GLPK_HTTP_SCHEME:  newGLPKClient,
+		GLPK_HTTPS_SCHEME: newGLPKClient,
+		// Plain HTTP URLs are the GLPK gateway, as with NewClient
+		"http":        newGLPKClient,
+		"https":       newGLPKClient,
+		INPROC_SCHEME: newInprocClient,
+		REPLAY_SCHEME: newReplayClient,
+	},
+}
+
+// Makes a backend selectable by the scheme of configuration strings
+// in Open. Returns puanerror.InvalidArgument if the scheme is taken,
+// or if it starts with RECORD_PREFIX.
+func Register(scheme string, backend Backend) error {
+	if scheme == "" || backend == nil {
+		return errors.Errorf(
+			"%w: backend and scheme are required",
+			puanerror.InvalidArgument,
+		)
+	}
+
+	backends.mutex.Lock()
+	defer backends.mutex.Unlock()
+
+	scheme = strings.ToLower(scheme)
+	if strings.HasPrefix(scheme, RECORD_PREFIX) {
+		return errors.Errorf(
+			"%w: scheme '%s' cannot start with %s",
+			puanerror.InvalidArgument,
+			scheme,
+			RECORD_PREFIX,
+		)
+	}
+
+	if _, exists := backends.backends[scheme]; exists {
+		return errors.Errorf(
+			"%w: solver backend already registered for scheme '%s'",
+			puanerror.InvalidArgument,
+			scheme,
+		)
+	}
+
+	backends.backends[scheme] = backend
+
+	return nil
+}
+
+// Schemes of the registered backends, sorted
+func Schemes() []string {
+	backends.mutex.RLock()
+	defer backends.mutex.RUnlock()
+
+	return slices.Sorted(maps.Keys(backends.backends))
+}
+
+// Creates a solver client from a configuration string, selecting the
+// backend by the scheme of the URL, e.g. "glpk+http://host:9000" for the
+// GLPK HTTP gateway, "inproc://" for the in-process solver or
+// "replay:testdata/golden" to replay golden files, see RECORD_PREFIX.
+func Open(config string, options Options) (puan.SolverClient, error) {
+	location, err := url.Parse(config)
+	if err != nil {
+		return nil, errors.Errorf(
+			"%w: invalid solver configuration '%s': %v",
+			puanerror.InvalidArgument,
+			config,
+			err,
+		)
+	}
+
+	if strings.HasPrefix(location.Scheme, RECORD_PREFIX) {
+		return openRecording(location, options)
+	}
+
+	backends.mutex.RLock()
+	backend, exists := backends.backends[location.Scheme]
+	backends.mutex.RUnlock()
+
+	if !exists {
+		return nil, errors.Errorf(
+			"%w: no solver backend for scheme '%s', expected one of %v",
+			puanerror.InvalidArgument,
+			location.Scheme,
+			Schemes(),
+		)
+	}
+
+	return backend(location, options)
+}
+
+func newGLPKClient(location *url.URL, options Options) (puan.SolverClient, error) {
+	baseURL, apiKey := newGLPKBaseURL(location)
+	if options.APIKey != "" {
+		apiKey = options.APIKey
+	}
+
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return glpk.NewClient(baseURL, apiKey, httpClient), nil
+}
+
+// Base URL of the gateway, without the GLPK prefix of the scheme
+// and without the apiKey query parameter
+func newGLPKBaseURL(location *url.URL) (string, string) {
+	base := *location
+	base.Scheme = strings.TrimPrefix(location.Scheme, "glpk+")
+
+	query := base.Query()
+	apiKey := query.Get("apiKey")
+	query.Del("apiKey")
+	base.RawQuery = query.Encode()
+
+	return strings.TrimSuffix(base.String(), "/"), apiKey
+}
+
+// Opens the backend of the scheme without the prefix, with
+// the golden query parameter as the directory of the golden files
+func openRecording(location *url.URL, options Options) (puan.SolverClient, error) {
+	inner := *location
+	inner.Scheme = strings.TrimPrefix(location.Scheme, RECORD_PREFIX)
+
+	query := inner.Query()
+	directory := query.Get("golden")
+	query.Del("golden")
+	inner.RawQuery = query.Encode()
+
+	if directory == "" {
+		return nil, errors.Errorf(
+			"%w: golden directory is required for recording, e.g. %s",
+			puanerror.InvalidArgument,
+			"record+inproc://?golden=testdata",
+		)
+	}
+
+	client, err := Open(inner.String(), options)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRecordingClient(client, directory), nil
+}
+
+// The directory is the path of the URL, e.g. "replay:testdata/golden"
+// or "replay:///var/golden"
+func newReplayClient(location *url.URL, _ Options) (puan.SolverClient, error) {
+	directory := location.Opaque
+	if directory == "" {
+		directory = location.Host + location.Path
+	}
+
+	if directory == "" {
+		return nil, errors.Errorf(
+			"%w: golden directory is required for replay, e.g. %s",
+			puanerror.InvalidArgument,
+			"replay:testdata",
+		)
+	}
+
+	return NewReplayClient(directory), nil
+}
+
+func newInprocClient(_ *url.URL, _ Options) (puan.SolverClient, error) {
+	return NewInprocClient(), nil
+}
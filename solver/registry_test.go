@@ -0,0 +1,148 @@
+package solver
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	glpk "github.com/ourstudio-se/puan-sdk-go/internal/gateway/glpk"
+	"github.com/ourstudio-se/puan-sdk-go/internal/pldag"
+	"github.com/ourstudio-se/puan-sdk-go/internal/weights"
+	"github.com/ourstudio-se/puan-sdk-go/puan"
+	"github.com/ourstudio-se/puan-sdk-go/puanerror"
+)
+
+func Test_Open_givenGLPKScheme_shouldReturnGLPKClient(t *testing.T) {
+	client, err := Open("glpk+http://127.0.0.1:9000", Options{})
+
+	require.NoError(t, err)
+	assert.IsType(t, &glpk.Client{}, client)
+}
+
+func Test_Open_givenInprocScheme_shouldReturnInprocClient(t *testing.T) {
+	client, err := Open("inproc://", Options{})
+
+	require.NoError(t, err)
+	assert.IsType(t, inprocClient{}, client)
+}
+
+func Test_Open_givenUnknownScheme_shouldReturnInvalidArgument(t *testing.T) {
+	_, err := Open("unknown://host", Options{})
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_Open_givenNoScheme_shouldReturnInvalidArgument(t *testing.T) {
+	_, err := Open("127.0.0.1:9000", Options{})
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_Register_shouldMakeBackendSelectable(t *testing.T) {
+	want := &inprocClient{}
+	err := Register("test+register", func(_ *url.URL, _ Options) (puan.SolverClient, error) {
+		return want, nil
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		backends.mutex.Lock()
+		defer backends.mutex.Unlock()
+		delete(backends.backends, "test+register")
+	})
+
+	client, err := Open("test+register://", Options{})
+
+	require.NoError(t, err)
+	assert.Same(t, want, client)
+	assert.Contains(t, Schemes(), "test+register")
+}
+
+func Test_Register_givenTakenScheme_shouldReturnInvalidArgument(t *testing.T) {
+	err := Register(INPROC_SCHEME, newInprocClient)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_Register_givenRecordPrefix_shouldReturnInvalidArgument(t *testing.T) {
+	err := Register(RECORD_PREFIX+"custom", newInprocClient)
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_Open_givenRecordPrefix_shouldRecordAndReplayGoldenFiles(t *testing.T) {
+	directory := t.TempDir()
+	recording, err := Open("record+inproc://?golden="+url.QueryEscape(directory), Options{})
+	require.NoError(t, err)
+	query := puan.NewSolverQuery(
+		pldag.NewPolyhedron([][]int{{1, 1}, {-1, -1}}, []int{1, -1}),
+		[]string{"x", "y"},
+		weights.Weights{"y": 1},
+	)
+
+	recorded, err := recording.Solve(query)
+	require.NoError(t, err)
+
+	replay, err := Open("replay:"+directory, Options{})
+	require.NoError(t, err)
+	replayed, err := replay.Solve(query)
+
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+}
+
+func Test_Open_givenRecordPrefixWithoutDirectory_shouldReturnInvalidArgument(t *testing.T) {
+	_, err := Open("record+inproc://", Options{})
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_Open_givenReplayWithoutDirectory_shouldReturnInvalidArgument(t *testing.T) {
+	_, err := Open("replay://", Options{})
+
+	assert.ErrorIs(t, err, puanerror.InvalidArgument)
+}
+
+func Test_newGLPKBaseURL(t *testing.T) {
+	testCases := []struct {
+		name       string
+		config     string
+		wantURL    string
+		wantAPIKey string
+	}{
+		{
+			name:    "http",
+			config:  "glpk+http://host:9000",
+			wantURL: "http://host:9000",
+		},
+		{
+			name:    "https with path",
+			config:  "glpk+https://host/solver/",
+			wantURL: "https://host/solver",
+		},
+		{
+			name:       "api key",
+			config:     "glpk+http://host:9000?apiKey=secret",
+			wantURL:    "http://host:9000",
+			wantAPIKey: "secret",
+		},
+		{
+			name:    "plain http",
+			config:  "http://host:9000",
+			wantURL: "http://host:9000",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			location, err := url.Parse(testCase.config)
+			require.NoError(t, err)
+
+			baseURL, apiKey := newGLPKBaseURL(location)
+
+			assert.Equal(t, testCase.wantURL, baseURL)
+			assert.Equal(t, testCase.wantAPIKey, apiKey)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	glpk "github.com/ourstudio-se/puan-sdk-go/internal/gateway/glpk"
+	"github.com/ourstudio-se/puan-sdk-go/internal/gateway/golden"
 	"github.com/ourstudio-se/puan-sdk-go/puan"
 )
 
@@ -18,3 +19,16 @@ func NewClient(
 		client,
 	)
 }
+
+// Solves queries with the client, recording each answer to a golden file
+// in the directory, named by the canonical hash of the polyhedron,
+// variables and weights of the query. See NewReplayClient.
+func NewRecordingClient(client puan.SolverClient, directory string) puan.SolverClient {
+	return golden.NewRecordingClient(client, directory)
+}
+
+// Serves queries from the golden files recorded in the directory, without
+// a solver, e.g. for tests. Queries not recorded fail with puanerror.NotFound.
+func NewReplayClient(directory string) puan.SolverClient {
+	return golden.NewReplayClient(directory)
+}
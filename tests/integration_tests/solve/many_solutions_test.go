@@ -17,6 +17,8 @@ import (
 func Test_CreateSolutionsBySelection_givenManyDependentSelections_shouldCreateSolutionForEach(
 	t *testing.T,
 ) {
+	seedFakeData(t)
+
 	creator := puan.NewRulesetCreator()
 	from := time.Now()
 	end := from.Add(1 * time.Hour)
@@ -66,6 +68,8 @@ func Test_CreateSolutionsBySelection_givenManyDependentSelections_shouldCreateSo
 func Test_CreateSolutionsBySelection_givenManyIndependentSelections_shouldCreateSolutionForEach(
 	t *testing.T,
 ) {
+	seedFakeData(t)
+
 	creator := puan.NewRulesetCreator()
 	from := time.Now()
 	end := from.Add(1 * time.Hour)
@@ -106,6 +110,8 @@ func Test_CreateSolutionsBySelection_givenManyIndependentSelections_shouldCreate
 func Test_CreateSolutionsBySelection_givenMixedSelections_shouldCreateSolutionForEach(
 	t *testing.T,
 ) {
+	seedFakeData(t)
+
 	creator := puan.NewRulesetCreator()
 	from := time.Now()
 	end := from.Add(1 * time.Hour)
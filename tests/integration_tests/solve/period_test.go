@@ -15,6 +15,8 @@ import (
 // Many items are included, but later not.
 // The solver should choose the earliest period despite the many items.
 func Test_manyItemsIncludedInPeriod(t *testing.T) {
+	seedFakeData(t)
+
 	creator := puan.NewRulesetCreator()
 
 	items := fake.New[[]string](
@@ -50,6 +52,8 @@ func Test_manyItemsIncludedInPeriod(t *testing.T) {
 
 // Items are included in later period. The solver should choose the earlier period.
 func Test_itemsIncludedInLaterPeriod_shouldChooseEarlierPeriod(t *testing.T) {
+	seedFakeData(t)
+
 	creator := puan.NewRulesetCreator()
 
 	items := fake.New[[]string](
@@ -86,6 +90,8 @@ func Test_itemsIncludedInLaterPeriod_shouldChooseEarlierPeriod(t *testing.T) {
 // Item is included in later period, and `from` is within that later period. The solver
 // should choose the later period, as the earlier is forbidden.
 func Test_itemsIncludedInLaterPeriod_andFromInLaterPeriod_shouldChooseLaterPeriod(t *testing.T) {
+	seedFakeData(t)
+
 	creator := puan.NewRulesetCreator()
 
 	items := fake.New[[]string](
@@ -126,6 +132,8 @@ func Test_itemsIncludedInLaterPeriod_andFromInLaterPeriod_shouldChooseLaterPerio
 func Test_itemsIncludedInLaterPeriod_andFromInEarlierPeriod_shouldChooseEarlierPeriod(
 	t *testing.T,
 ) {
+	seedFakeData(t)
+
 	creator := puan.NewRulesetCreator()
 
 	items := fake.New[[]string](
@@ -210,6 +218,8 @@ func Test_itemSelectableInPeriod_givenItemSelected_shouldChoosePeriod(t *testing
 func Test_itemSelectableInPeriod_andManyItemsIncludedInThatPeriod_givenItemSelected_shouldChoosePeriod(
 	t *testing.T,
 ) {
+	seedFakeData(t)
+
 	creator := puan.NewRulesetCreator()
 
 	selectableItems := []string{"itemX"}
@@ -275,6 +285,8 @@ func Test_itemSelectableInPeriod_andManyItemsIncludedInThatPeriod_givenItemSelec
 func Test_includedPackageInEarlierPeriod_withPreferred_shouldChooseEarlierPeriodWithPreferredPackage(
 	t *testing.T,
 ) {
+	seedFakeData(t)
+
 	creator := puan.NewRulesetCreator()
 
 	startTime := time.Now()
@@ -419,6 +431,8 @@ func Test_givenTimeEnabledWithoutTimeboundConstraints_andLateFromSpecified_shoul
 // item2 has many consequences in the first period.
 // The solver should choose the first period when item2 is selected.
 func Test_givenXORWithManyConsequencesInFirstPeriod_selectExpensiveItem_shouldChooseFirstPeriod(t *testing.T) {
+	seedFakeData(t)
+
 	creator := puan.NewRulesetCreator()
 	startTime := time.Now()
 	endTime := startTime.Add(1 * time.Hour)
@@ -468,6 +482,8 @@ func Test_givenXORWithManyConsequencesInFirstPeriod_selectExpensiveItem_shouldCh
 // all other items are preferred in the first period.
 // The solver should choose the first period when item1 is selected.
 func Test_givenXORWithManyPreferredInFirstPeriod_selectNonPreferredItem_shouldChooseFirstPeriod(t *testing.T) {
+	seedFakeData(t)
+
 	creator := puan.NewRulesetCreator()
 	startTime := time.Now()
 	endTime := startTime.Add(1 * time.Hour)
@@ -14,6 +14,8 @@ import (
 // Global conditional rule with preferred the changes in periods. When selecting the condition in the second period,
 // the preferred item should be the one for the second period.
 func Test_givenConditionalRuleWithDifferentPreferreds_shouldReturnPreferredItemForCurrentPeriod(t *testing.T) {
+	seedFakeData(t)
+
 	item1 := fake.New[string]()
 	item2 := fake.New[string]()
 	condition := fake.New[string]()
@@ -64,6 +66,8 @@ func Test_givenConditionalRuleWithDifferentPreferreds_shouldReturnPreferredItemF
 // Global XOR rule with preferred the changes in periods.
 // When solving in the second period, the preferred item should be the one for the second period.
 func Test_givenXORRuleWithDifferentPreferred_shouldReturnPreferredItemForCurrentPeriod(t *testing.T) {
+	seedFakeData(t)
+
 	item1 := fake.New[string]()
 	item2 := fake.New[string]()
 
@@ -2,15 +2,46 @@
 package solve
 
 import (
+	"hash/fnv"
+	mathrand "math/rand"
+	"os"
 	"testing"
 
+	"github.com/go-faker/faker/v4"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ourstudio-se/puan-sdk-go/internal/gateway/glpk"
 	"github.com/ourstudio-se/puan-sdk-go/puan"
+	"github.com/ourstudio-se/puan-sdk-go/solver"
 )
 
-var solutionCreator = puan.NewSolutionCreator(glpk.NewDefaultClient("http://127.0.0.1:9000"))
+var solutionCreator = puan.NewSolutionCreator(newSolverClient())
+
+// The GLPK API by default. PUAN_TEST_SOLVER selects another backend, see
+// solver.Open, e.g. "replay:testdata/golden" to run without the GLPK API.
+func newSolverClient() puan.SolverClient {
+	config := os.Getenv("PUAN_TEST_SOLVER")
+	if config == "" {
+		return glpk.NewDefaultClient("http://127.0.0.1:9000")
+	}
+
+	client, err := solver.Open(config, solver.Options{})
+	if err != nil {
+		panic(err)
+	}
+
+	return client
+}
+
+// Seeds fake data by the name of the test, so that a test sends the same
+// queries against the GLPK API as when its golden files were recorded,
+// whichever tests run before it, e.g. with -run, -count or -shuffle
+func seedFakeData(t *testing.T) {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(t.Name()))
+	seed := int64(hash.Sum64())
+	faker.SetRandomSource(faker.NewSafeSource(mathrand.NewSource(seed)))
+}
 
 // Test_exactlyOnePackage_selectPreferredThenNotPreferred
 // Ref: test_select_exactly_one_constrainted_component_with_additional_requirements
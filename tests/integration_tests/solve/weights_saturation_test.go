@@ -15,6 +15,8 @@ import (
 // The first selection (least prioritised) should be selected as none
 // of the other selections are in conflict with it.
 func Test_givenVeryManySelections_earliestSelectionShouldBeSelected(t *testing.T) {
+	seedFakeData(t)
+
 	creator, primitives := rulesetCreatorForSaturationTest()
 
 	_ = creator.AddPrimitives("a", "b", "c")